@@ -2,9 +2,10 @@ package httphandler
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"github.com/nipuntalukdar/hllserver/hll"
-	"io"
+	"math"
 	"net/http"
 	"strconv"
 )
@@ -28,6 +29,11 @@ type HttpUpdateLogHandler struct {
 	allowed []string
 }
 
+type HttpUpdateHashesHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
 type HttpGetCardinalityHandler struct {
 	hlc     *hll.HllContainer
 	allowed []string
@@ -38,6 +44,65 @@ type HttpUpdateExpiryHandler struct {
 	allowed []string
 }
 
+type HttpExpireAtHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpTTLHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpPersistHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpMergeLogHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpSketchHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+	// sketches in the Redis hyperloglog format
+	redis bool
+}
+
+type HttpIntersectionHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpDifferenceHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpKeysHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpInfoHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
+type HttpRenameHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+	// copies instead of renaming
+	copy bool
+}
+
+type HttpQuarantineHandler struct {
+	hlc     *hll.HllContainer
+	allowed []string
+}
+
 func NewHttpAddLogHandler(hlc *hll.HllContainer) *HttpAddLogHandler {
 	return &HttpAddLogHandler{hlc: hlc, allowed: []string{http.MethodGet}}
 }
@@ -50,6 +115,10 @@ func NewHttpUpdateLogHandler(hlc *hll.HllContainer) *HttpUpdateLogHandler {
 	return &HttpUpdateLogHandler{hlc: hlc, allowed: []string{http.MethodPost}}
 }
 
+func NewHttpUpdateHashesHandler(hlc *hll.HllContainer) *HttpUpdateHashesHandler {
+	return &HttpUpdateHashesHandler{hlc: hlc, allowed: []string{http.MethodPost}}
+}
+
 func NewHttpGetCardinalityHandler(hlc *hll.HllContainer) *HttpGetCardinalityHandler {
 	return &HttpGetCardinalityHandler{hlc: hlc, allowed: []string{http.MethodGet}}
 }
@@ -58,6 +127,60 @@ func NewHttpUpdateExpiryHandler(hlc *hll.HllContainer) *HttpUpdateExpiryHandler
 	return &HttpUpdateExpiryHandler{hlc: hlc, allowed: []string{http.MethodGet}}
 }
 
+func NewHttpExpireAtHandler(hlc *hll.HllContainer) *HttpExpireAtHandler {
+	return &HttpExpireAtHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpTTLHandler(hlc *hll.HllContainer) *HttpTTLHandler {
+	return &HttpTTLHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpPersistHandler(hlc *hll.HllContainer) *HttpPersistHandler {
+	return &HttpPersistHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpMergeLogHandler(hlc *hll.HllContainer) *HttpMergeLogHandler {
+	return &HttpMergeLogHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpSketchHandler(hlc *hll.HllContainer) *HttpSketchHandler {
+	return &HttpSketchHandler{hlc: hlc,
+		allowed: []string{http.MethodGet, http.MethodPut, http.MethodPost}}
+}
+
+func NewHttpRedisSketchHandler(hlc *hll.HllContainer) *HttpSketchHandler {
+	return &HttpSketchHandler{hlc: hlc,
+		allowed: []string{http.MethodGet, http.MethodPut, http.MethodPost}, redis: true}
+}
+
+func NewHttpIntersectionHandler(hlc *hll.HllContainer) *HttpIntersectionHandler {
+	return &HttpIntersectionHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpDifferenceHandler(hlc *hll.HllContainer) *HttpDifferenceHandler {
+	return &HttpDifferenceHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpKeysHandler(hlc *hll.HllContainer) *HttpKeysHandler {
+	return &HttpKeysHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpInfoHandler(hlc *hll.HllContainer) *HttpInfoHandler {
+	return &HttpInfoHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpRenameHandler(hlc *hll.HllContainer) *HttpRenameHandler {
+	return &HttpRenameHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
+func NewHttpCopyHandler(hlc *hll.HllContainer) *HttpRenameHandler {
+	return &HttpRenameHandler{hlc: hlc, allowed: []string{http.MethodGet}, copy: true}
+}
+
+func NewHttpQuarantineHandler(hlc *hll.HllContainer) *HttpQuarantineHandler {
+	return &HttpQuarantineHandler{hlc: hlc, allowed: []string{http.MethodGet}}
+}
+
 func checkMethod(req *http.Request, w http.ResponseWriter, allowedMethods []string) bool {
 	for _, method := range allowedMethods {
 		if req.Method == method {
@@ -87,6 +210,38 @@ func successStatus(w http.ResponseWriter) {
 	w.Write(jdata)
 }
 
+// Fails the request with 503 if the updates can't be persisted
+func checkStore(hlc *hll.HllContainer, w http.ResponseWriter) bool {
+	if err := hlc.StoreErr(); err != nil {
+		failureStatus(w, http.StatusServiceUnavailable, err.Error())
+		return false
+	}
+	return true
+}
+
+// Writes 503 if the change isn't durable in the write ahead log, returns false
+// then
+func checkDurable(w http.ResponseWriter, err error) bool {
+	if err == hll.ErrNotDurable {
+		failureStatus(w, http.StatusServiceUnavailable, err.Error())
+		return false
+	}
+	return true
+}
+
+// Writes the status of a change of an existing log, 404 if the log doesn't
+// exist and 503 if the change isn't durable
+func changeStatus(w http.ResponseWriter, ok bool, err error) {
+	if !checkDurable(w, err) {
+		return
+	}
+	if !ok {
+		failureStatus(w, http.StatusNotFound, hll.ErrLogNotExists.Error())
+	} else {
+		successStatus(w)
+	}
+}
+
 func checkLogKey(req *http.Request, w http.ResponseWriter) string {
 	req.ParseForm()
 	data := req.Form
@@ -103,25 +258,93 @@ func checkLogKey(req *http.Request, w http.ResponseWriter) string {
 	return logkeys[0]
 }
 
+func readBody(req *http.Request, w http.ResponseWriter) ([]byte, bool) {
+	dlen := int(req.ContentLength)
+	if dlen > mAXUPDLENGTH || dlen <= 0 {
+		failureStatus(w, http.StatusBadRequest, "Invalid length for request body")
+		return nil, false
+	}
+	body := make([]byte, dlen)
+	read := 0
+	for {
+		l, err := req.Body.Read(body[read:])
+		read += l
+		if read == dlen {
+			break
+		}
+		if err != nil {
+			failureStatus(w, http.StatusBadRequest, "Couldn't read the request body completely")
+			return nil, false
+		}
+	}
+	return body, true
+}
+
+func checkTwoLogKeys(req *http.Request, w http.ResponseWriter) (string, string, bool) {
+	req.ParseForm()
+	logkeys := req.Form["logkey"]
+	if len(logkeys) != 2 || len(logkeys[0]) == 0 || len(logkeys[1]) == 0 {
+		failureStatus(w, http.StatusBadRequest,
+			"logkey must have exactly two non-empty values")
+		return "", "", false
+	}
+	return logkeys[0], logkeys[1], true
+}
+
+func setCardinalityStatus(w http.ResponseWriter, card uint64, bound uint64, err error) {
+	if err != nil {
+		failureStatus(w, http.StatusConflict, err.Error())
+		return
+	}
+	jsonm := map[string]interface{}{"status": "success", "cardinality": card,
+		"errorbound": bound}
+	jdata, _ := json.Marshal(jsonm)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(jdata)
+}
+
 func checkExpiryVal(req *http.Request, w http.ResponseWriter) (uint64, bool) {
+	return checkUintVal(req, w, "expiry")
+}
+
+func checkUintVal(req *http.Request, w http.ResponseWriter, name string) (uint64, bool) {
 	req.ParseForm()
 	data := req.Form
-	expiry, ok := data["expiry"]
+	vals, ok := data[name]
 	if !ok {
-		failureStatus(w, http.StatusBadRequest, "expiry key missing")
+		failureStatus(w, http.StatusBadRequest, name+" key missing")
 		return 0, false
 	}
-	if len(expiry) != 1 || len(expiry[0]) == 0 {
+	if len(vals) != 1 || len(vals[0]) == 0 {
 		failureStatus(w, http.StatusBadRequest,
-			"expiry must have one and only one non-empty value")
+			name+" must have one and only one non-empty value")
 		return 0, false
 	}
-	expiry_time, err := strconv.ParseUint(expiry[0], 10, 64)
+	val, err := strconv.ParseUint(vals[0], 10, 64)
 	if err != nil {
-		failureStatus(w, http.StatusBadRequest, "Invalid values for expiry")
+		failureStatus(w, http.StatusBadRequest, "Invalid values for "+name)
 		return 0, false
 	}
-	return expiry_time, true
+	return val, true
+}
+
+// Returns the value of the optional boolean parameter, false if it is missing
+func checkBoolVal(req *http.Request, w http.ResponseWriter, name string) (bool, bool) {
+	req.ParseForm()
+	vals, ok := req.Form[name]
+	if !ok {
+		return false, true
+	}
+	if len(vals) != 1 {
+		failureStatus(w, http.StatusBadRequest, "multiple values for "+name)
+		return false, false
+	}
+	val, err := strconv.ParseBool(vals[0])
+	if err != nil {
+		failureStatus(w, http.StatusBadRequest, "Invalid value for "+name)
+		return false, false
+	}
+	return val, true
 }
 
 func (hl *HttpAddLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -149,7 +372,63 @@ func (hl *HttpAddLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 			}
 		}
 	}
-	hl.hlc.AddLog(logkey, nil, expiry_time)
+	precision := uint64(0)
+	precisions, ok := data["precision"]
+	if ok {
+		if len(precisions) != 1 {
+			failureStatus(w, http.StatusBadRequest, "multiple values for precision")
+			return
+		}
+		precision, err = strconv.ParseUint(precisions[0], 10, 8)
+		if err != nil {
+			failureStatus(w, http.StatusBadRequest, "Invalid value for precision")
+			return
+		}
+	}
+	algo := hll.CLASSIC
+	algos, ok := data["algorithm"]
+	if ok {
+		if len(algos) != 1 {
+			failureStatus(w, http.StatusBadRequest, "multiple values for algorithm")
+			return
+		}
+		switch algos[0] {
+		case "classic":
+			algo = hll.CLASSIC
+		case "hllpp":
+			algo = hll.HLLPP
+		case "redis":
+			algo = hll.REDIS
+		default:
+			failureStatus(w, http.StatusBadRequest, "Invalid value for algorithm")
+			return
+		}
+	}
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	var salt []byte
+	if salts, ok := data["salt"]; ok {
+		if len(salts) != 1 {
+			failureStatus(w, http.StatusBadRequest, "multiple values for salt")
+			return
+		}
+		salt, err = hex.DecodeString(salts[0])
+		if err != nil {
+			failureStatus(w, http.StatusBadRequest, "Invalid value for salt")
+			return
+		}
+	}
+	err = hl.hlc.CreateLog(logkey, expiry_time, uint8(precision), algo, salt)
+	if !checkDurable(w, err) {
+		return
+	} else if err == hll.ErrLogExists {
+		failureStatus(w, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		failureStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	successStatus(w)
 }
 
@@ -161,12 +440,11 @@ func (hl *HttpDelLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	if logkey == "" {
 		return
 	}
-	ok := hl.hlc.DelLog(logkey)
-	if !ok {
-		failureStatus(w, http.StatusInternalServerError, "Error in deleting logkey")
-	} else {
-		successStatus(w)
+	if !checkStore(hl.hlc, w) {
+		return
 	}
+	ok, err := hl.hlc.DelLog(logkey)
+	changeStatus(w, ok, err)
 }
 
 func (hl *HttpUpdateLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -174,24 +452,10 @@ func (hl *HttpUpdateLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Reque
 		return
 	}
 	// Now read the json of update request
-	dlen := int(req.ContentLength)
-	if dlen > mAXUPDLENGTH || dlen == 0 {
-		failureStatus(w, http.StatusBadRequest, "Invalid length for updatelog handler")
+	body, ok := readBody(req, w)
+	if !ok {
 		return
 	}
-	body := make([]byte, dlen)
-	read := 0
-	for {
-		l, err := req.Body.Read(body[read:])
-		if err != nil && err != io.EOF {
-			failureStatus(w, http.StatusBadRequest, "Couldn't read the request body completely")
-			return
-		}
-		read += l
-		if read == dlen {
-			break
-		}
-	}
 	var decoded map[string]interface{}
 	err := json.Unmarshal(body, &decoded)
 	if err != nil {
@@ -229,7 +493,75 @@ func (hl *HttpUpdateLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Reque
 			return
 		}
 	}
-	hl.hlc.AddMLog(logkey, bindata, expiry_time)
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	if _, err := hl.hlc.AddMLog(logkey, bindata, expiry_time); checkDurable(w, err) {
+		successStatus(w)
+	}
+}
+
+// Updates the log with the hashes of the items computed by the client. Hashes
+// are json numbers, unsigned or signed 64 bit integers
+func (hl *HttpUpdateHashesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	body, ok := readBody(req, w)
+	if !ok {
+		return
+	}
+	var decoded struct {
+		Logkey string        `json:"logkey"`
+		Hashes []json.Number `json:"hashes"`
+		Expiry string        `json:"expiry"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		failureStatus(w, http.StatusBadRequest, "Couldn't decode json data")
+		return
+	}
+	if decoded.Logkey == "" {
+		failureStatus(w, http.StatusBadRequest, "Logkey is missing")
+		return
+	}
+	if decoded.Hashes == nil {
+		failureStatus(w, http.StatusBadRequest, "hashes are missing")
+		return
+	}
+	hashes := make([]uint64, len(decoded.Hashes))
+	for i, num := range decoded.Hashes {
+		hash, err := strconv.ParseUint(string(num), 10, 64)
+		if err != nil {
+			var shash int64
+			if shash, err = strconv.ParseInt(string(num), 10, 64); err != nil {
+				failureStatus(w, http.StatusBadRequest, "Invalid value for hash")
+				return
+			}
+			hash = uint64(shash)
+		}
+		hashes[i] = hash
+	}
+	expiry_time := uint64(0)
+	if decoded.Expiry != "" {
+		var err error
+		expiry_time, err = strconv.ParseUint(decoded.Expiry, 10, 64)
+		if err != nil {
+			failureStatus(w, http.StatusBadRequest, "Invalid value for expiry")
+			return
+		}
+	}
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	if _, err := hl.hlc.AddHashes(decoded.Logkey, hashes, expiry_time); !checkDurable(w, err) {
+		return
+	} else if err == hll.ErrSaltedLog {
+		failureStatus(w, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		failureStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	successStatus(w)
 }
 
@@ -237,12 +569,43 @@ func (hl *HttpGetCardinalityHandler) ServeHTTP(w http.ResponseWriter, req *http.
 	if !checkMethod(req, w, hl.allowed) {
 		return
 	}
-	logkey := checkLogKey(req, w)
-	if logkey == "" {
+	req.ParseForm()
+	logkeys, ok := req.Form["logkey"]
+	if !ok {
+		failureStatus(w, http.StatusBadRequest, "logkey is missing")
 		return
 	}
-	card := hl.hlc.GetCardinality(logkey)
-	jsonm := map[string]interface{}{"status": "success", "cardinality": card}
+	for _, logkey := range logkeys {
+		if len(logkey) == 0 {
+			failureStatus(w, http.StatusBadRequest, "logkey must be non-empty")
+			return
+		}
+	}
+	var estimator hll.Estimator
+	if name := req.Form.Get("estimator"); name != "" {
+		if estimator, ok = hll.GetEstimator(name); !ok {
+			failureStatus(w, http.StatusBadRequest, "Invalid estimator")
+			return
+		}
+	}
+	var est hll.Estimate
+	var err error
+	if len(logkeys) == 1 {
+		est, err = hl.hlc.GetLogEstimate(logkeys[0], estimator)
+		if err != nil {
+			failureStatus(w, http.StatusNotFound, err.Error())
+			return
+		}
+	} else {
+		est, err = hl.hlc.GetUnionEstimate(logkeys, estimator)
+		if err != nil {
+			failureStatus(w, http.StatusConflict, err.Error())
+			return
+		}
+	}
+	jsonm := map[string]interface{}{"status": "success", "cardinality": est.Cardinality,
+		"stderror": est.StdError, "lower": est.Lower, "upper": est.Upper,
+		"estimator": est.Estimator}
 	jdata, _ := json.Marshal(jsonm)
 	w.Header().Set("Content-type", "application/json")
 	w.Write(jdata)
@@ -260,9 +623,322 @@ func (hl *HttpUpdateExpiryHandler) ServeHTTP(w http.ResponseWriter, req *http.Re
 	if !ok {
 		return
 	}
-	if !hl.hlc.UpdateExpiry(logkey, expiry) {
-		failureStatus(w, http.StatusInternalServerError, "Failed to update expiry")
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	ok, err := hl.hlc.UpdateExpiry(logkey, expiry)
+	changeStatus(w, ok, err)
+}
+
+func (hl *HttpExpireAtHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	timestamp, ok := checkUintVal(req, w, "timestamp")
+	if !ok {
+		return
+	}
+	if timestamp == 0 {
+		failureStatus(w, http.StatusBadRequest, "timestamp must be positive")
+		return
+	}
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	ok, err := hl.hlc.ExpireAt(logkey, timestamp)
+	changeStatus(w, ok, err)
+}
+
+// ttl is the remaining time to live in seconds, -1 and expiry 0 if the log has
+// no expiry
+func (hl *HttpTTLHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	ttl := hl.hlc.TTL(logkey)
+	expiry, ok := hl.hlc.GetExpiry(logkey)
+	if ttl == -2 || !ok {
+		failureStatus(w, http.StatusNotFound, hll.ErrLogNotExists.Error())
+		return
+	}
+	jsonm := map[string]interface{}{"status": "success", "ttl": ttl, "expiry": expiry}
+	jdata, _ := json.Marshal(jsonm)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(jdata)
+}
+
+func (hl *HttpPersistHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	// a log without expiry is left as it is
+	persisted, err := hl.hlc.Persist(logkey)
+	changeStatus(w, persisted || hl.hlc.Exists(logkey), err)
+}
+
+func (hl *HttpMergeLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	srckeys, ok := req.Form["srclogkey"]
+	if !ok {
+		failureStatus(w, http.StatusBadRequest, "srclogkey is missing")
+		return
+	}
+	for _, srckey := range srckeys {
+		if len(srckey) == 0 {
+			failureStatus(w, http.StatusBadRequest, "srclogkey must be non-empty")
+			return
+		}
+	}
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	if err := hl.hlc.Merge(logkey, srckeys); !checkDurable(w, err) {
+		return
+	} else if err != nil {
+		failureStatus(w, http.StatusConflict, err.Error())
 	} else {
 		successStatus(w)
 	}
 }
+
+func (hl *HttpIntersectionHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	keya, keyb, ok := checkTwoLogKeys(req, w)
+	if !ok {
+		return
+	}
+	card, bound, err := hl.hlc.GetIntersectionCardinality(keya, keyb)
+	setCardinalityStatus(w, card, bound, err)
+}
+
+func (hl *HttpDifferenceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	keya, keyb, ok := checkTwoLogKeys(req, w)
+	if !ok {
+		return
+	}
+	card, bound, err := hl.hlc.GetDifferenceCardinality(keya, keyb)
+	setCardinalityStatus(w, card, bound, err)
+}
+
+func (hl *HttpSketchHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	// read the sketch before parsing the form, so that a form encoded
+	// content type doesn't consume the body
+	var sketch []byte
+	if req.Method != http.MethodGet {
+		var ok bool
+		sketch, ok = readBody(req, w)
+		if !ok {
+			return
+		}
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	if req.Method == http.MethodGet {
+		var err error
+		if hl.redis {
+			sketch, err = hl.hlc.ExportRedisLog(logkey)
+		} else {
+			var ok bool
+			if sketch, ok = hl.hlc.ExportLog(logkey); !ok {
+				err = hll.ErrLogNotExists
+			}
+		}
+		if err == hll.ErrLogNotExists {
+			failureStatus(w, http.StatusNotFound, "logkey doesn't exist")
+			return
+		} else if err != nil {
+			failureStatus(w, http.StatusConflict, err.Error())
+			return
+		}
+		w.Header().Set("Content-type", "application/octet-stream")
+		w.Write(sketch)
+		return
+	}
+	expiry_time := uint64(0)
+	if _, ok := req.Form["expiry"]; ok {
+		expiry_time, ok = checkExpiryVal(req, w)
+		if !ok {
+			return
+		}
+	}
+	if !checkStore(hl.hlc, w) {
+		return
+	}
+	// PUT replaces the slots of the log key, POST merges into them
+	var err error
+	if hl.redis {
+		err = hl.hlc.ImportRedisLog(logkey, sketch, expiry_time, req.Method == http.MethodPost)
+	} else {
+		err = hl.hlc.ImportLog(logkey, sketch, expiry_time, req.Method == http.MethodPost)
+	}
+	if !checkDurable(w, err) {
+		return
+	} else if err == hll.ErrInvalidSketch {
+		failureStatus(w, http.StatusBadRequest, err.Error())
+	} else if err != nil {
+		failureStatus(w, http.StatusConflict, err.Error())
+	} else {
+		successStatus(w)
+	}
+}
+
+// Lists the keys quarantined on restore with the reason and the time
+func (hl *HttpQuarantineHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	qkeys, err := hl.hlc.Quarantined()
+	if err != nil {
+		failureStatus(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	keys := make([]map[string]interface{}, len(qkeys))
+	for i, qk := range qkeys {
+		keys[i] = map[string]interface{}{"key": qk.Key, "reason": qk.Reason, "time": qk.Time}
+	}
+	jsonm := map[string]interface{}{"status": "success", "keys": keys}
+	jdata, _ := json.Marshal(jsonm)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(jdata)
+}
+
+func (hl *HttpKeysHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	req.ParseForm()
+	cursor := req.Form.Get("cursor")
+	if cursor == "" {
+		cursor = "0"
+	}
+	count := uint64(0)
+	if _, ok := req.Form["count"]; ok {
+		if count, ok = checkUintVal(req, w, "count"); !ok {
+			return
+		}
+	}
+	keys, next, err := hl.hlc.Scan(cursor, req.Form.Get("match"), int(min(count, math.MaxInt32)))
+	if err != nil {
+		failureStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	jkeys := make([]map[string]interface{}, len(keys))
+	for i, key := range keys {
+		jkeys[i] = map[string]interface{}{"key": key.Key, "ttl": key.TTL}
+	}
+	jsonm := map[string]interface{}{"status": "success", "cursor": next, "keys": jkeys}
+	jdata, _ := json.Marshal(jsonm)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(jdata)
+}
+
+// Returns the name of the algorithm as taken by addlogkey
+func algoName(algo uint8) string {
+	switch algo {
+	case hll.HLLPP:
+		return "hllpp"
+	case hll.REDIS:
+		return "redis"
+	}
+	return "classic"
+}
+
+// ctime and mtime are the creation and last modification unix times, 0 for
+// logs stored before the times were recorded
+func (hl *HttpInfoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	info, err := hl.hlc.GetLogInfo(logkey)
+	if err != nil {
+		failureStatus(w, http.StatusNotFound, err.Error())
+		return
+	}
+	jsonm := map[string]interface{}{"status": "success", "algorithm": algoName(info.Algorithm),
+		"precision": info.Precision, "salted": info.Salted, "expiry": info.Expiry,
+		"nonzeroregisters": info.NonZeroSlots, "pendingupdates": info.PendingUpdates,
+		"serializedsize": info.SerializedSize, "persisted": info.Persisted,
+		"ctime": info.Ctime, "mtime": info.Mtime}
+	jdata, _ := json.Marshal(jsonm)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(jdata)
+}
+
+// A rename replaces an existing newlogkey unless nx is true, a copy only if
+// replace is true
+func (hl *HttpRenameHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !checkMethod(req, w, hl.allowed) {
+		return
+	}
+	logkey := checkLogKey(req, w)
+	if logkey == "" {
+		return
+	}
+	newkeys := req.Form["newlogkey"]
+	if len(newkeys) != 1 || len(newkeys[0]) == 0 {
+		failureStatus(w, http.StatusBadRequest,
+			"newlogkey must have one and only one non-empty value")
+		return
+	}
+	flag := "nx"
+	if hl.copy {
+		flag = "replace"
+	}
+	val, ok := checkBoolVal(req, w, flag)
+	if !ok || !checkStore(hl.hlc, w) {
+		return
+	}
+	var err error
+	if hl.copy {
+		err = hl.hlc.Copy(logkey, newkeys[0], val)
+	} else {
+		err = hl.hlc.Rename(logkey, newkeys[0], !val)
+	}
+	switch err {
+	case nil:
+		successStatus(w)
+	case hll.ErrLogNotExists:
+		failureStatus(w, http.StatusNotFound, err.Error())
+	case hll.ErrLogExists:
+		failureStatus(w, http.StatusConflict, err.Error())
+	case hll.ErrNotDurable:
+		failureStatus(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		failureStatus(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,391 @@
+package resphandler
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/nipuntalukdar/hllserver/hll"
+	"github.com/nipuntalukdar/hllserver/hllogs"
+	"io"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	mAXARGS    = 1024 * 1024
+	mAXBULKLEN = 1024 * 1024 * 10
+	// args are allocated as they arrive, not for the announced count
+	iNITARGS = 16
+)
+
+var errProtocol = errors.New("Protocol error")
+
+type respCommand struct {
+	// arity includes the command name, negative arity means at least -arity
+	arity int
+	fn    func(rs *RespServer, args [][]byte, w *bufio.Writer)
+	// the command updates logs, it is rejected if updates can't be persisted
+	update bool
+}
+
+var commands = map[string]*respCommand{
+	"PING":    {-1, ping, false},
+	"QUIT":    {1, quit, false},
+	"SELECT":  {2, selectdb, false},
+	"COMMAND": {-1, command, false},
+	"INFO":    {-1, info, false},
+	"PFADD":   {-2, pfadd, true},
+	"PFCOUNT": {-2, pfcount, false},
+	"PFMERGE": {-2, pfmerge, true},
+	"DEL":     {-2, del, true},
+	"EXISTS":  {-2, exists, false},
+	"EXPIRE":  {3, expire, true},
+	"PEXPIRE": {3, pexpire, true},
+	"TTL":     {2, ttl, false},
+	"PTTL":    {2, pttl, false},
+	"PERSIST": {2, persist, true},
+}
+
+type RespServer struct {
+	hlc      *hll.HllContainer
+	listener net.Listener
+	started  time.Time
+	// open connections, closed on Stop
+	conns    map[net.Conn]bool
+	connlock *sync.Mutex
+	connwg   *sync.WaitGroup
+}
+
+func NewRespServer(hlc *hll.HllContainer, addr string) (*RespServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &RespServer{hlc: hlc, listener: listener, started: time.Now(),
+		conns: make(map[net.Conn]bool), connlock: &sync.Mutex{}, connwg: &sync.WaitGroup{}}, nil
+}
+
+func (rs *RespServer) Serve() error {
+	for {
+		conn, err := rs.listener.Accept()
+		if err != nil {
+			return err
+		}
+		rs.connlock.Lock()
+		rs.conns[conn] = true
+		rs.connwg.Add(1)
+		rs.connlock.Unlock()
+		go rs.handleConn(conn)
+	}
+}
+
+// Stops listening, closes the open connections and waits for their commands
+// to complete
+func (rs *RespServer) Stop() {
+	rs.listener.Close()
+	rs.connlock.Lock()
+	for conn := range rs.conns {
+		conn.Close()
+	}
+	rs.connlock.Unlock()
+	rs.connwg.Wait()
+}
+
+func (rs *RespServer) handleConn(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		rs.connlock.Lock()
+		delete(rs.conns, conn)
+		rs.connlock.Unlock()
+		rs.connwg.Done()
+	}()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			if err == errProtocol {
+				hllogs.Log.Infof("Protocol error from %s", conn.RemoteAddr())
+				writeError(w, "ERR Protocol error")
+				w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		name := strings.ToUpper(string(args[0]))
+		cmd, ok := commands[name]
+		if !ok {
+			writeError(w, fmt.Sprintf("ERR unknown command '%s'", args[0]))
+		} else if (cmd.arity > 0 && len(args) != cmd.arity) ||
+			(cmd.arity < 0 && len(args) < -cmd.arity) {
+			writeError(w, fmt.Sprintf("ERR wrong number of arguments for '%s' command",
+				strings.ToLower(name)))
+		} else if err := rs.hlc.StoreErr(); cmd.update && err != nil {
+			writeError(w, "ERR "+err.Error())
+		} else {
+			cmd.fn(rs, args, w)
+		}
+		// flush only when there are no more pipelined commands
+		if r.Buffered() == 0 {
+			if w.Flush() != nil {
+				return
+			}
+		}
+		if name == "QUIT" {
+			w.Flush()
+			return
+		}
+	}
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return nil, errProtocol
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
+
+// Reads a command either as an array of bulk strings or as an inline command
+func readCommand(r *bufio.Reader) ([][]byte, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		var args [][]byte
+		for _, arg := range bytes.Fields(line) {
+			args = append(args, append([]byte(nil), arg...))
+		}
+		return args, nil
+	}
+	numargs, err := strconv.Atoi(string(line[1:]))
+	if err != nil || numargs > mAXARGS {
+		return nil, errProtocol
+	}
+	if numargs <= 0 {
+		return nil, nil
+	}
+	args := make([][]byte, 0, min(numargs, iNITARGS))
+	for len(args) < numargs {
+		line, err = readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, errProtocol
+		}
+		arglen, err := strconv.Atoi(string(line[1:]))
+		if err != nil || arglen < 0 || arglen > mAXBULKLEN {
+			return nil, errProtocol
+		}
+		arg := make([]byte, arglen+2)
+		if _, err = io.ReadFull(r, arg); err != nil {
+			return nil, err
+		}
+		if arg[arglen] != '\r' || arg[arglen+1] != '\n' {
+			return nil, errProtocol
+		}
+		args = append(args, arg[:arglen])
+	}
+	return args, nil
+}
+
+func writeSimple(w *bufio.Writer, msg string) {
+	w.WriteString("+" + msg + "\r\n")
+}
+
+func writeError(w *bufio.Writer, msg string) {
+	w.WriteString("-" + msg + "\r\n")
+}
+
+func writeInt(w *bufio.Writer, val int64) {
+	w.WriteString(":" + strconv.FormatInt(val, 10) + "\r\n")
+}
+
+func writeBulk(w *bufio.Writer, data []byte) {
+	w.WriteString("$" + strconv.Itoa(len(data)) + "\r\n")
+	w.Write(data)
+	w.WriteString("\r\n")
+}
+
+func keys(args [][]byte) []string {
+	ret := make([]string, len(args))
+	for i, arg := range args {
+		ret[i] = string(arg)
+	}
+	return ret
+}
+
+func ping(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	if len(args) > 2 {
+		writeError(w, "ERR wrong number of arguments for 'ping' command")
+	} else if len(args) == 2 {
+		writeBulk(w, args[1])
+	} else {
+		writeSimple(w, "PONG")
+	}
+}
+
+func quit(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	writeSimple(w, "OK")
+}
+
+func selectdb(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	if string(args[1]) != "0" {
+		writeError(w, "ERR DB index is out of range")
+	} else {
+		writeSimple(w, "OK")
+	}
+}
+
+// Clients like redis-cli query the command table on connect, an empty table
+// makes them fall back to defaults
+func command(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	w.WriteString("*0\r\n")
+}
+
+func info(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	numlogs, numexpiry := rs.hlc.NumLogs()
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Server\r\nredis_mode:standalone\r\nprocess_id:%d\r\n", os.Getpid())
+	fmt.Fprintf(&b, "tcp_port:%s\r\n", portOf(rs.listener.Addr()))
+	fmt.Fprintf(&b, "uptime_in_seconds:%d\r\n", int64(time.Since(rs.started).Seconds()))
+	fmt.Fprintf(&b, "\r\n# Keyspace\r\ndb0:keys=%d,expires=%d,avg_ttl=0\r\n", numlogs,
+		numexpiry)
+	writeBulk(w, []byte(b.String()))
+}
+
+func portOf(addr net.Addr) string {
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return ""
+	}
+	return port
+}
+
+func pfadd(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	key := string(args[1])
+	created := !rs.hlc.Exists(key)
+	changed := false
+	var err error
+	if len(args) == 2 {
+		err = rs.hlc.AddLog(key, nil, 0, 0, hll.CLASSIC)
+	} else {
+		changed, err = rs.hlc.AddMLog(key, args[2:], 0)
+	}
+	writeChange(w, changed || created, err)
+}
+
+// Writes 1 if the change was made and 0 if not, an error if the change isn't
+// durable
+func writeChange(w *bufio.Writer, changed bool, err error) {
+	if err != nil {
+		writeError(w, "ERR "+err.Error())
+	} else if changed {
+		writeInt(w, 1)
+	} else {
+		writeInt(w, 0)
+	}
+}
+
+func pfcount(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	if len(args) == 2 {
+		writeInt(w, int64(rs.hlc.GetCardinality(string(args[1]))))
+		return
+	}
+	card, err := rs.hlc.GetUnionCardinality(keys(args[1:]))
+	if err != nil {
+		writeError(w, "ERR "+err.Error())
+		return
+	}
+	writeInt(w, int64(card))
+}
+
+func pfmerge(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	if err := rs.hlc.Merge(string(args[1]), keys(args[2:])); err != nil {
+		writeError(w, "ERR "+err.Error())
+		return
+	}
+	writeSimple(w, "OK")
+}
+
+func del(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	deleted := int64(0)
+	for _, key := range keys(args[1:]) {
+		ok, err := rs.hlc.DelLog(key)
+		if err != nil {
+			writeError(w, "ERR "+err.Error())
+			return
+		}
+		if ok {
+			deleted++
+		}
+	}
+	writeInt(w, deleted)
+}
+
+func exists(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	count := int64(0)
+	for _, key := range keys(args[1:]) {
+		if rs.hlc.Exists(key) {
+			count++
+		}
+	}
+	writeInt(w, count)
+}
+
+func expire(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	expireIn(rs, args, w, 1000, "expire")
+}
+
+func pexpire(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	expireIn(rs, args, w, 1, "pexpire")
+}
+
+// Expires the key in the time given in units of milliseconds
+func expireIn(rs *RespServer, args [][]byte, w *bufio.Writer, unit int64, name string) {
+	key := string(args[1])
+	delta, err := strconv.ParseInt(string(args[2]), 10, 64)
+	if err != nil {
+		writeError(w, "ERR value is not an integer or out of range")
+		return
+	}
+	// like redis, the expiry in milliseconds must fit in 64 bits
+	if delta > (math.MaxInt64-time.Now().UnixMilli())/unit {
+		writeError(w, "ERR invalid expire time in '"+name+"' command")
+		return
+	}
+	// like redis, a non-positive expiry deletes the key
+	if delta <= 0 {
+		changed, err := rs.hlc.DelLog(key)
+		writeChange(w, changed, err)
+		return
+	}
+	changed, err := rs.hlc.PExpire(key, uint64(delta*unit))
+	writeChange(w, changed, err)
+}
+
+func ttl(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	writeInt(w, rs.hlc.TTL(string(args[1])))
+}
+
+func pttl(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	writeInt(w, rs.hlc.PTTL(string(args[1])))
+}
+
+func persist(rs *RespServer, args [][]byte, w *bufio.Writer) {
+	changed, err := rs.hlc.Persist(string(args[1]))
+	writeChange(w, changed, err)
+}
@@ -0,0 +1,193 @@
+package resphandler
+
+import (
+	"bufio"
+	"github.com/nipuntalukdar/hllserver/hll"
+	"github.com/nipuntalukdar/hllserver/hllogs"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	hllogs.InitLogger(10, 1024, filepath.Join(os.TempDir(), "resp_test.log"), "INFO")
+	os.Exit(m.Run())
+}
+
+type respClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+	t    *testing.T
+}
+
+func newRespClient(t *testing.T) *respClient {
+	hlc := hll.NewHllContainer(16, nil)
+	rs, err := NewRespServer(hlc, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go rs.Serve()
+	conn, err := net.Dial("tcp", rs.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	t.Cleanup(func() {
+		conn.Close()
+		rs.Stop()
+		hlc.Shutdown()
+	})
+	return &respClient{conn: conn, r: bufio.NewReader(conn), t: t}
+}
+
+func (rc *respClient) send(raw string) {
+	if _, err := rc.conn.Write([]byte(raw)); err != nil {
+		rc.t.Fatal(err)
+	}
+}
+
+// Sends the command as an array of bulk strings
+func (rc *respClient) call(args ...string) string {
+	var b strings.Builder
+	b.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		b.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+	rc.send(b.String())
+	return rc.reply()
+}
+
+// Returns the reply with its type byte, bulk strings as $ and the data
+func (rc *respClient) reply() string {
+	line, err := rc.r.ReadString('\n')
+	if err != nil {
+		rc.t.Fatal(err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line[0] != '$' {
+		return line
+	}
+	size, _ := strconv.Atoi(line[1:])
+	data := make([]byte, size+2)
+	if _, err = io.ReadFull(rc.r, data); err != nil {
+		rc.t.Fatal(err)
+	}
+	return "$" + string(data[:size])
+}
+
+func expect(t *testing.T, got string, want string) {
+	t.Helper()
+	if got != want {
+		t.Fatalf("Expected reply %q, got %q", want, got)
+	}
+}
+
+func TestRespParsing(t *testing.T) {
+	rc := newRespClient(t)
+	expect(t, rc.call("PING"), "+PONG")
+	expect(t, rc.call("ping", "hello"), "$hello")
+	rc.send("PING\r\n")
+	expect(t, rc.reply(), "+PONG")
+	rc.send("  PFADD   inline a b  \r\n")
+	expect(t, rc.reply(), ":1")
+	expect(t, rc.call("PFCOUNT", "inline"), ":2")
+	// empty lines and arrays are skipped
+	rc.send("\r\n*0\r\nPING\r\n")
+	expect(t, rc.reply(), "+PONG")
+
+	// pipelined commands are answered in order
+	rc.send("*3\r\n$5\r\nPFADD\r\n$1\r\np\r\n$1\r\nx\r\n" +
+		"*2\r\n$7\r\nPFCOUNT\r\n$1\r\np\r\nPING\r\n")
+	expect(t, rc.reply(), ":1")
+	expect(t, rc.reply(), ":1")
+	expect(t, rc.reply(), "+PONG")
+
+	expect(t, rc.call("NOSUCH"), "-ERR unknown command 'NOSUCH'")
+	expect(t, rc.call("PFADD"), "-ERR wrong number of arguments for 'pfadd' command")
+	expect(t, rc.call("TTL", "a", "b"), "-ERR wrong number of arguments for 'ttl' command")
+	expect(t, rc.call("PING", "a", "b"), "-ERR wrong number of arguments for 'ping' command")
+	expect(t, rc.call("SELECT", "1"), "-ERR DB index is out of range")
+	expect(t, rc.call("QUIT"), "+OK")
+	if _, err := rc.r.ReadByte(); err == nil {
+		t.Fatal("Connection must be closed after QUIT")
+	}
+}
+
+func TestRespProtocolErrors(t *testing.T) {
+	for _, raw := range []string{
+		"*x\r\n",
+		"*2\r\n$4\r\nPING\r\n:1\r\n",
+		"*1\r\n$-1\r\n",
+		"*1\r\n$4\r\nPINGxx\r\n",
+		"*1\r\n$" + strconv.Itoa(mAXBULKLEN+1) + "\r\n",
+		"*" + strconv.Itoa(mAXARGS+1) + "\r\n",
+	} {
+		rc := newRespClient(t)
+		rc.send(raw)
+		expect(t, rc.reply(), "-ERR Protocol error")
+		if _, err := rc.r.ReadByte(); err == nil {
+			t.Fatalf("Connection must be closed after protocol error in %q", raw)
+		}
+	}
+	// a large announced count isn't allocated before the args arrive
+	rc := newRespClient(t)
+	rc.send("*" + strconv.Itoa(mAXARGS) + "\r\n$4\r\nPING\r\n")
+	rc.conn.(*net.TCPConn).CloseWrite()
+	if _, err := rc.r.ReadByte(); err == nil {
+		t.Fatal("Incomplete command must not be answered")
+	}
+}
+
+func TestRespCommands(t *testing.T) {
+	rc := newRespClient(t)
+	expect(t, rc.call("PFADD", "a", "x", "y", "z"), ":1")
+	expect(t, rc.call("PFADD", "a", "x"), ":0")
+	expect(t, rc.call("PFADD", "empty"), ":1")
+	expect(t, rc.call("PFADD", "empty"), ":0")
+	expect(t, rc.call("PFADD", "b", "z", "w"), ":1")
+	expect(t, rc.call("PFCOUNT", "a"), ":3")
+	expect(t, rc.call("PFCOUNT", "missing"), ":0")
+	expect(t, rc.call("PFCOUNT", "a", "b"), ":4")
+	expect(t, rc.call("PFMERGE", "m", "a", "b"), "+OK")
+	expect(t, rc.call("PFCOUNT", "m"), ":4")
+	expect(t, rc.call("EXISTS", "a", "b", "missing", "a"), ":3")
+
+	expect(t, rc.call("TTL", "a"), ":-1")
+	expect(t, rc.call("TTL", "missing"), ":-2")
+	expect(t, rc.call("EXPIRE", "a", "100"), ":1")
+	if ttl := rc.call("TTL", "a"); ttl != ":100" && ttl != ":99" {
+		t.Fatalf("Unexpected TTL %q", ttl)
+	}
+	expect(t, rc.call("EXPIRE", "missing", "100"), ":0")
+	expect(t, rc.call("EXPIRE", "a", "x"), "-ERR value is not an integer or out of range")
+	expect(t, rc.call("EXPIRE", "a", "9223372036854775807"),
+		"-ERR invalid expire time in 'expire' command")
+	expect(t, rc.call("PEXPIRE", "a", "1500"), ":1")
+	if pttl, _ := strconv.Atoi(strings.TrimPrefix(rc.call("PTTL", "a"), ":")); pttl <= 1000 ||
+		pttl > 1500 {
+		t.Fatalf("Unexpected PTTL %d", pttl)
+	}
+	expect(t, rc.call("TTL", "a"), ":2")
+	expect(t, rc.call("PTTL", "missing"), ":-2")
+	expect(t, rc.call("PEXPIRE", "a", "9223372036854775807"),
+		"-ERR invalid expire time in 'pexpire' command")
+	expect(t, rc.call("PERSIST", "a"), ":1")
+	expect(t, rc.call("PERSIST", "a"), ":0")
+	expect(t, rc.call("PTTL", "a"), ":-1")
+	expect(t, rc.call("TTL", "a"), ":-1")
+	// a non-positive expiry deletes the key
+	expect(t, rc.call("EXPIRE", "b", "0"), ":1")
+	expect(t, rc.call("EXISTS", "b"), ":0")
+
+	expect(t, rc.call("DEL", "a", "m", "missing"), ":2")
+	expect(t, rc.call("DEL", "a"), ":0")
+	expect(t, rc.call("EXISTS", "a", "m"), ":0")
+	if info := rc.call("INFO"); !strings.Contains(info, "db0:keys=1,expires=0") {
+		t.Fatalf("Unexpected INFO %q", info)
+	}
+}
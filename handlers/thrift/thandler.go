@@ -15,8 +15,20 @@ func registerTypes() {
 	gob.Register(hllthrift.NewAddLogCmd())
 	gob.Register(hllthrift.NewUpdateLogCmd())
 	gob.Register(hllthrift.NewUpdateLogMValCmd())
+	gob.Register(hllthrift.NewUpdateLogHashesCmd())
 	gob.Register(hllthrift.NewUpdateExpiryCmd())
+	gob.Register(hllthrift.NewExpireAtCmd())
+	gob.Register(hllthrift.NewMergeLogCmd())
 	gob.Register(hllthrift.NewCardinalityResponse())
+	gob.Register(hllthrift.NewSetCardinalityResponse())
+	gob.Register(hllthrift.NewImportLogCmd())
+	gob.Register(hllthrift.NewSketchResponse())
+	gob.Register(hllthrift.NewTTLResponse())
+	gob.Register(hllthrift.NewKeyTTL())
+	gob.Register(hllthrift.NewScanResponse())
+	gob.Register(hllthrift.NewLogInfoResponse())
+	gob.Register(hllthrift.NewRenameLogCmd())
+	gob.Register(hllthrift.NewCopyLogCmd())
 }
 
 func init() {
@@ -31,42 +43,294 @@ func NewThriftHandler(hlc *hll.HllContainer) (*ThriftHandler, error) {
 }
 
 func (th *ThriftHandler) AddLog(ctx context.Context, add *hllthrift.AddLogCmd) (hllthrift.Status, error) {
-	th.hlc.AddLog(add.Key, nil, uint64(add.Expiry))
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	if add.Precision < 0 || add.Precision > 255 || add.Algorithm < 0 || add.Algorithm > 255 {
+		return hllthrift.Status_FAILURE, nil
+	}
+	var salt []byte
+	if len(add.Salt) > 0 {
+		salt = add.Salt
+	}
+	err := th.hlc.CreateLog(add.Key, uint64(add.Expiry), uint8(add.Precision),
+		uint8(add.Algorithm), salt)
+	if err == hll.ErrLogExists {
+		return hllthrift.Status_KEY_EXISTS, nil
+	} else if err != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
 	return hllthrift.Status_SUCCESS, nil
 }
 
 func (th *ThriftHandler) UpdateExpiry(ctx context.Context, upde *hllthrift.UpdateExpiryCmd) (hllthrift.Status, error) {
-	if th.hlc.UpdateExpiry(upde.Key, uint64(upde.Expiry)) {
-		return hllthrift.Status_SUCCESS, nil
-	} else {
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return changeStatus(th.hlc.UpdateExpiry(upde.Key, uint64(upde.Expiry))), nil
+}
+
+func (th *ThriftHandler) ExpireAt(ctx context.Context, expa *hllthrift.ExpireAtCmd) (hllthrift.Status, error) {
+	if expa.Timestamp <= 0 {
+		return hllthrift.Status_FAILURE, nil
+	}
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return changeStatus(th.hlc.ExpireAt(expa.Key, uint64(expa.Timestamp))), nil
+}
+
+// TTL is the remaining time to live in seconds, -1 and Expiry 0 if the log has
+// no expiry
+func (th *ThriftHandler) GetTTL(ctx context.Context, key string) (*hllthrift.TTLResponse, error) {
+	r := hllthrift.NewTTLResponse()
+	r.Key = key
+	ttl := th.hlc.TTL(key)
+	expiry, ok := th.hlc.GetExpiry(key)
+	if ttl == -2 || !ok {
+		r.Status = hllthrift.Status_KEY_NOT_EXISTS
+		return r, nil
+	}
+	r.Status = hllthrift.Status_SUCCESS
+	r.Expiry = int64(expiry)
+	r.TTL = ttl
+	return r, nil
+}
+
+// Removes the expiry of the log, succeeds also if the log has no expiry
+func (th *ThriftHandler) Persist(ctx context.Context, key string) (hllthrift.Status, error) {
+	if th.hlc.StoreErr() != nil {
 		return hllthrift.Status_FAILURE, nil
 	}
+	persisted, err := th.hlc.Persist(key)
+	return changeStatus(persisted || th.hlc.Exists(key), err), nil
 }
 
 func (th *ThriftHandler) Update(ctx context.Context, updl *hllthrift.UpdateLogCmd) (hllthrift.Status, error) {
-	th.hlc.AddLog(updl.Key, updl.Data, uint64(updl.Expiry))
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	if th.hlc.AddLog(updl.Key, updl.Data, uint64(updl.Expiry), 0, hll.CLASSIC) != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
 	return hllthrift.Status_SUCCESS, nil
 }
 
 func (th *ThriftHandler) UpdateM(ctx context.Context, updlm *hllthrift.UpdateLogMValCmd) (hllthrift.Status, error) {
-	th.hlc.AddMLog(updlm.Key, updlm.Data, uint64(updlm.Expiry))
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	if _, err := th.hlc.AddMLog(updlm.Key, updlm.Data, uint64(updlm.Expiry)); err != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return hllthrift.Status_SUCCESS, nil
+}
+
+// The hashes are the 64 bit or 32 bit hashes of the items as signed integers
+func (th *ThriftHandler) UpdateHashes(ctx context.Context, hupd *hllthrift.UpdateLogHashesCmd) (hllthrift.Status, error) {
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	hashes := make([]uint64, len(hupd.Hashes))
+	for i, h := range hupd.Hashes {
+		hashes[i] = uint64(h)
+	}
+	if _, err := th.hlc.AddHashes(hupd.Key, hashes, uint64(hupd.Expiry)); err != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
 	return hllthrift.Status_SUCCESS, nil
 }
 
 func (th *ThriftHandler) DelLog(ctx context.Context, key string) (hllthrift.Status, error) {
-	ret := th.hlc.DelLog(key)
-	if ret {
-		return hllthrift.Status_SUCCESS, nil
-	} else {
+	if th.hlc.StoreErr() != nil {
 		return hllthrift.Status_FAILURE, nil
 	}
+	return changeStatus(th.hlc.DelLog(key)), nil
 }
 
-func (th *ThriftHandler) GetCardinality(ctx context.Context, key string) (*hllthrift.CardinalityResponse, error) {
-	card := th.hlc.GetCardinality(key)
+// Status of a change of an existing log, FAILURE if the change isn't durable
+func changeStatus(ok bool, err error) hllthrift.Status {
+	if err != nil {
+		return hllthrift.Status_FAILURE
+	}
+	if !ok {
+		return hllthrift.Status_KEY_NOT_EXISTS
+	}
+	return hllthrift.Status_SUCCESS
+}
+
+func (th *ThriftHandler) GetCardinality(ctx context.Context, key string,
+	estimator string) (*hllthrift.CardinalityResponse, error) {
 	r := hllthrift.NewCardinalityResponse()
-	r.Status = hllthrift.Status_SUCCESS
 	r.Key = key
+	est, ok := getEstimator(estimator)
+	if !ok {
+		r.Status = hllthrift.Status_FAILURE
+		return r, nil
+	}
+	hest, err := th.hlc.GetLogEstimate(key, est)
+	if err != nil {
+		r.Status = hllthrift.Status_KEY_NOT_EXISTS
+		return r, nil
+	}
+	r.Status = hllthrift.Status_SUCCESS
+	setEstimate(r, hest)
+	return r, nil
+}
+
+// An empty name is the default estimator of the server
+func getEstimator(name string) (hll.Estimator, bool) {
+	if name == "" {
+		return nil, true
+	}
+	return hll.GetEstimator(name)
+}
+
+func setEstimate(r *hllthrift.CardinalityResponse, est hll.Estimate) {
+	r.Cardinality = int64(est.Cardinality)
+	r.StdError = est.StdError
+	r.Lower = int64(est.Lower)
+	r.Upper = int64(est.Upper)
+	r.Estimator = est.Estimator
+}
+
+func (th *ThriftHandler) Merge(ctx context.Context, mrg *hllthrift.MergeLogCmd) (hllthrift.Status, error) {
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	if th.hlc.Merge(mrg.Key, mrg.SourceKeys) != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return hllthrift.Status_SUCCESS, nil
+}
+
+func (th *ThriftHandler) GetUnionCardinality(ctx context.Context, keys []string,
+	estimator string) (*hllthrift.CardinalityResponse, error) {
+	r := hllthrift.NewCardinalityResponse()
+	hest, ok := getEstimator(estimator)
+	if !ok {
+		r.Status = hllthrift.Status_FAILURE
+		return r, nil
+	}
+	est, err := th.hlc.GetUnionEstimate(keys, hest)
+	if err != nil {
+		r.Status = hllthrift.Status_FAILURE
+		return r, nil
+	}
+	r.Status = hllthrift.Status_SUCCESS
+	setEstimate(r, est)
+	return r, nil
+}
+
+func (th *ThriftHandler) GetIntersectionCardinality(ctx context.Context, key1 string,
+	key2 string) (*hllthrift.SetCardinalityResponse, error) {
+	return setCardinalityResponse(th.hlc.GetIntersectionCardinality(key1, key2)), nil
+}
+
+func (th *ThriftHandler) GetDifferenceCardinality(ctx context.Context, key1 string,
+	key2 string) (*hllthrift.SetCardinalityResponse, error) {
+	return setCardinalityResponse(th.hlc.GetDifferenceCardinality(key1, key2)), nil
+}
+
+func setCardinalityResponse(card uint64, bound uint64, err error) *hllthrift.SetCardinalityResponse {
+	r := hllthrift.NewSetCardinalityResponse()
+	if err != nil {
+		r.Status = hllthrift.Status_FAILURE
+		return r
+	}
+	r.Status = hllthrift.Status_SUCCESS
 	r.Cardinality = int64(card)
+	r.ErrorBound = int64(bound)
+	return r
+}
+
+func (th *ThriftHandler) ExportLog(ctx context.Context, key string) (*hllthrift.SketchResponse, error) {
+	r := hllthrift.NewSketchResponse()
+	r.Key = key
+	sketch, ok := th.hlc.ExportLog(key)
+	if !ok {
+		r.Status = hllthrift.Status_KEY_NOT_EXISTS
+		return r, nil
+	}
+	r.Status = hllthrift.Status_SUCCESS
+	r.Sketch = sketch
 	return r, nil
 }
+
+func (th *ThriftHandler) ImportLog(ctx context.Context, imp *hllthrift.ImportLogCmd) (hllthrift.Status, error) {
+	if th.hlc.StoreErr() != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	if th.hlc.ImportLog(imp.Key, imp.Sketch, uint64(imp.Expiry), imp.Merge) != nil {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return hllthrift.Status_SUCCESS, nil
+}
+
+// Returns a page of the keys matching the glob pattern Match, an empty Match
+// matches all the keys. The scan starts with Cursor "0" and is complete when
+// the returned Cursor is "0"
+func (th *ThriftHandler) Scan(ctx context.Context, cursor string, match string,
+	count int32) (*hllthrift.ScanResponse, error) {
+	r := hllthrift.NewScanResponse()
+	keys, next, err := th.hlc.Scan(cursor, match, int(count))
+	if err != nil {
+		r.Status = hllthrift.Status_FAILURE
+		return r, nil
+	}
+	r.Status = hllthrift.Status_SUCCESS
+	r.Cursor = next
+	r.Keys = make([]*hllthrift.KeyTTL, len(keys))
+	for i, key := range keys {
+		r.Keys[i] = &hllthrift.KeyTTL{Key: key.Key, TTL: key.TTL}
+	}
+	return r, nil
+}
+
+func (th *ThriftHandler) GetLogInfo(ctx context.Context, key string) (*hllthrift.LogInfoResponse, error) {
+	r := hllthrift.NewLogInfoResponse()
+	r.Key = key
+	info, err := th.hlc.GetLogInfo(key)
+	if err != nil {
+		r.Status = hllthrift.Status_KEY_NOT_EXISTS
+		return r, nil
+	}
+	r.Status = hllthrift.Status_SUCCESS
+	r.Algorithm = hllthrift.Algorithm(info.Algorithm)
+	r.Precision = int32(info.Precision)
+	r.Salted = info.Salted
+	r.Expiry = int64(info.Expiry)
+	r.NonZeroRegisters = int64(info.NonZeroSlots)
+	r.PendingUpdates = int64(info.PendingUpdates)
+	r.SerializedSize = int64(info.SerializedSize)
+	r.Persisted = info.Persisted
+	r.CreateTime = int64(info.Ctime)
+	r.ModifyTime = int64(info.Mtime)
+	return r, nil
+}
+
+func (th *ThriftHandler) Rename(ctx context.Context, ren *hllthrift.RenameLogCmd) (hllthrift.Status, error) {
+	if th.hlc.StoreErr() != nil || ren.NewKey == "" {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return copyStatus(th.hlc.Rename(ren.Key, ren.NewKey, !ren.NoOverwrite)), nil
+}
+
+func (th *ThriftHandler) Copy(ctx context.Context, cpy *hllthrift.CopyLogCmd) (hllthrift.Status, error) {
+	if th.hlc.StoreErr() != nil || cpy.NewKey == "" {
+		return hllthrift.Status_FAILURE, nil
+	}
+	return copyStatus(th.hlc.Copy(cpy.Key, cpy.NewKey, cpy.Replace)), nil
+}
+
+func copyStatus(err error) hllthrift.Status {
+	switch err {
+	case nil:
+		return hllthrift.Status_SUCCESS
+	case hll.ErrLogNotExists:
+		return hllthrift.Status_KEY_NOT_EXISTS
+	case hll.ErrLogExists:
+		return hllthrift.Status_KEY_EXISTS
+	}
+	return hllthrift.Status_FAILURE
+}
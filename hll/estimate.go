@@ -0,0 +1,278 @@
+package hll
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Branches of the estimator used for a cardinality estimate
+const (
+	LINEARCOUNTING = "linear_counting"
+	RAW            = "raw"
+	LARGERANGE     = "large_range"
+	BIASCORRECTED  = "bias_corrected"
+	LOGLOGBETA     = "loglog_beta"
+	ERTLIMPROVED   = "ertl_improved"
+	MLE            = "mle"
+)
+
+// z-score of the two sided 95% confidence interval
+const zNINETYFIVE float64 = 1.959964
+
+type Estimate struct {
+	Cardinality uint64
+	// relative standard error of the estimate
+	StdError float64
+	// 95% confidence interval of the cardinality
+	Lower     uint64
+	Upper     uint64
+	Estimator string
+}
+
+func newEstimate(card uint64, estimator string, stderr float64) Estimate {
+	cardf := float64(card)
+	delta := cardf * stderr * zNINETYFIVE
+	return Estimate{Cardinality: card, StdError: stderr,
+		Lower: uint64(math.Max(0, math.Floor(cardf-delta))),
+		Upper: uint64(math.Ceil(cardf + delta)), Estimator: estimator}
+}
+
+// An Estimator computes the cardinality of a log from the histogram of its
+// slot values. hist[k] is the number of slots with value k, len(hist) is
+// q + 2 where q is the number of hash bits left after the slot index. Returns
+// the cardinality and the estimator branch used
+type Estimator interface {
+	Estimate(hist []uint32, precision uint8, algo uint8) (uint64, string)
+}
+
+type defaultEstimator struct{}
+
+type logLogBetaEstimator struct{}
+
+type ertlImprovedEstimator struct{}
+
+type mleEstimator struct{}
+
+var estimators = map[string]Estimator{
+	"default":    defaultEstimator{},
+	"loglogbeta": logLogBetaEstimator{},
+	"ertl":       ertlImprovedEstimator{},
+	"mle":        mleEstimator{},
+}
+
+// name of the estimator used when no estimator is given for a query
+var defEstimator atomic.Value
+
+func init() {
+	defEstimator.Store("default")
+}
+
+// Returns the estimator with the name, one of default, loglogbeta, ertl and mle
+func GetEstimator(name string) (Estimator, bool) {
+	est, ok := estimators[name]
+	return est, ok
+}
+
+// Sets the estimator used when no estimator is given for a query
+func SetDefaultEstimator(name string) bool {
+	_, ok := estimators[name]
+	if ok {
+		defEstimator.Store(name)
+	}
+	return ok
+}
+
+func histSum(hist []uint32) float64 {
+	sum := 0.0
+	for k, count := range hist {
+		sum += float64(count) * math.Ldexp(1, -k)
+	}
+	return sum
+}
+
+// The original estimator of Flajolet et al for the classic algorithm and the
+// bias corrected estimator of Heule et al for the other algorithms
+func (defaultEstimator) Estimate(hist []uint32, precision uint8, algo uint8) (uint64, string) {
+	numslot := uint32(1) << precision
+	numslotf := float64(numslot)
+	zeros := hist[0]
+	ret := alpha(numslot) * numslotf * numslotf / histSum(hist)
+	if algo != CLASSIC {
+		return hllppCardinality(precision, ret, zeros)
+	}
+	if ret <= 2.5*numslotf {
+		if zeros != 0 {
+			return uint64(numslotf * math.Log(numslotf/float64(zeros))), LINEARCOUNTING
+		}
+		return uint64(ret), RAW
+	} else if ret <= cMP2 {
+		return uint64(ret), RAW
+	}
+	ret = -(tWOPO32F * math.Log(1.0-ret/tWOPO32F))
+	return uint64(ret), LARGERANGE
+}
+
+func hllppCardinality(precision uint8, rawest float64, zeros uint32) (uint64, string) {
+	numslotf := float64(uint32(1) << precision)
+	estimator := RAW
+	if rawest <= 5*numslotf {
+		rawest -= estimateBias(precision, rawest)
+		estimator = BIASCORRECTED
+	}
+	if zeros != 0 {
+		lc := uint64(numslotf * math.Log(numslotf/float64(zeros)))
+		if lc <= thresholdData[precision-mINPRECISION] {
+			return lc, LINEARCOUNTING
+		}
+	}
+	return uint64(rawest + 0.5), estimator
+}
+
+// LogLog-Beta, the raw estimate with the zero slots corrected by a polynomial
+// in the number of zero slots, with no switch over to linear counting
+func (logLogBetaEstimator) Estimate(hist []uint32, precision uint8, algo uint8) (uint64, string) {
+	numslot := uint32(1) << precision
+	numslotf := float64(numslot)
+	ez := float64(hist[0])
+	coef := betaData[precision-mINPRECISION]
+	zl := math.Log(ez + 1)
+	beta := coef[0] * ez
+	zlpow := 1.0
+	for _, c := range coef[1:] {
+		zlpow *= zl
+		beta += c * zlpow
+	}
+	ret := alpha(numslot) * numslotf * (numslotf - ez) / (histSum(hist) + beta)
+	return uint64(ret + 0.5), LOGLOGBETA
+}
+
+// The improved raw estimator from "New cardinality estimation algorithms for
+// HyperLogLog sketches" (Ertl)
+func (ertlImprovedEstimator) Estimate(hist []uint32, precision uint8, algo uint8) (uint64, string) {
+	numslotf := float64(uint32(1) << precision)
+	q := len(hist) - 2
+	z := numslotf * ertlTau(1-float64(hist[q+1])/numslotf)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + float64(hist[k]))
+	}
+	z += numslotf * ertlSigma(float64(hist[0])/numslotf)
+	if math.IsInf(z, 1) {
+		return 0, ERTLIMPROVED
+	}
+	ret := numslotf * numslotf / (2 * math.Ln2 * z)
+	return uint64(ret + 0.5), ERTLIMPROVED
+}
+
+func ertlSigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y := 1.0
+	z := x
+	for {
+		x *= x
+		prevz := z
+		z += x * y
+		y += y
+		if prevz == z {
+			return z
+		}
+	}
+}
+
+func ertlTau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y := 1.0
+	z := 1 - x
+	for {
+		x = math.Sqrt(x)
+		prevz := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if prevz == z {
+			return z / 3
+		}
+	}
+}
+
+// The maximum likelihood estimator from "New cardinality estimation algorithms
+// for HyperLogLog sketches" (Ertl), solved with the secant method
+func (mleEstimator) Estimate(hist []uint32, precision uint8, algo uint8) (uint64, string) {
+	numslotf := float64(uint32(1) << precision)
+	q := len(hist) - 2
+	if float64(hist[q+1]) == numslotf {
+		return math.MaxUint64, MLE
+	}
+	kmin := 0
+	for hist[kmin] == 0 {
+		kmin++
+	}
+	kminp := kmin
+	if kminp < 1 {
+		kminp = 1
+	}
+	kmax := q + 1
+	for hist[kmax] == 0 {
+		kmax--
+	}
+	kmaxp := kmax
+	if kmaxp > q {
+		kmaxp = q
+	}
+	z := 0.0
+	for k := kmaxp; k >= kminp; k-- {
+		z = 0.5*z + float64(hist[k])
+	}
+	z = math.Ldexp(z, -kminp)
+	c := float64(hist[q+1])
+	if q >= 1 {
+		c += float64(hist[kmaxp])
+	}
+	a := z + float64(hist[0])
+	b := z + math.Ldexp(float64(hist[q+1]), -q)
+	mp := numslotf - float64(hist[0])
+	if mp == 0 {
+		return 0, MLE
+	}
+	var x float64
+	if b <= 1.5*a {
+		x = mp / (0.5*b + a)
+	} else {
+		x = mp / b * math.Log1p(b/a)
+	}
+	eps := 0.01 / math.Sqrt(numslotf)
+	deltax := x
+	prevg := 0.0
+	for deltax > x*eps {
+		_, exp := math.Frexp(x)
+		kappa := exp + 1
+		kx := kappa
+		if kmaxp > kx {
+			kx = kmaxp
+		}
+		xp := math.Ldexp(x, -kx-1)
+		xpp := xp * xp
+		h := xp - xpp/3 + xpp*xpp*(1.0/45-xpp/472.5)
+		for k := kappa - 1; k >= kmaxp; k-- {
+			h = (xp + h*(1-h)) / (xp + (1 - h))
+			xp *= 2
+		}
+		g := c * h
+		for k := kmaxp - 1; k >= kminp; k-- {
+			h = (xp + h*(1-h)) / (xp + (1 - h))
+			g += float64(hist[k]) * h
+			xp *= 2
+		}
+		g += x * a
+		if g > prevg && mp >= g {
+			deltax *= (mp - g) / (g - prevg)
+		} else {
+			deltax = 0
+		}
+		x += deltax
+		prevg = g
+	}
+	return uint64(numslotf*x + 0.5), MLE
+}
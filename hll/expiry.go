@@ -0,0 +1,195 @@
+package hll
+
+import (
+	"container/heap"
+	"github.com/nipuntalukdar/hllserver/hllogs"
+	"github.com/nipuntalukdar/hllserver/hllstore"
+	"sync/atomic"
+	"time"
+)
+
+// The logs with an expiry are kept per map in a min-heap ordered by expiry,
+// guarded by the lock of the map. The cleanup runs every cLEANUPINTERVAL and
+// removes the expired logs of a map cLEANUPBATCH at a time, so that the
+// updates of the map aren't held up while many logs expire together. The
+// expiries are unix times in milliseconds
+const (
+	cLEANUPINTERVAL = 100 * time.Millisecond
+	cLEANUPBATCH    = 256
+)
+
+type expiryHeap []*hyperlog
+
+func (eh expiryHeap) Len() int {
+	return len(eh)
+}
+
+func (eh expiryHeap) Less(i, j int) bool {
+	return eh[i].expiry < eh[j].expiry
+}
+
+func (eh expiryHeap) Swap(i, j int) {
+	eh[i], eh[j] = eh[j], eh[i]
+	eh[i].expidx = i
+	eh[j].expidx = j
+}
+
+func (eh *expiryHeap) Push(x interface{}) {
+	hlog := x.(*hyperlog)
+	hlog.expidx = len(*eh)
+	*eh = append(*eh, hlog)
+}
+
+func (eh *expiryHeap) Pop() interface{} {
+	old := *eh
+	n := len(old)
+	hlog := old[n-1]
+	old[n-1] = nil
+	hlog.expidx = -1
+	*eh = old[:n-1]
+	return hlog
+}
+
+func nowMillis() uint64 {
+	return uint64(time.Now().UnixMilli())
+}
+
+// Returns the expiry in seconds in milliseconds, capped at the largest expiry
+// the store keeps
+func secondsToMillis(expiry uint64) uint64 {
+	if expiry > hllstore.MaxExpiry/1000 {
+		return hllstore.MaxExpiry
+	}
+	return expiry * 1000
+}
+
+// Returns the expiry in milliseconds rounded up to seconds
+func millisToSeconds(expiry uint64) uint64 {
+	return expiry/1000 + min(expiry%1000, 1)
+}
+
+// Returns the unix time delta milliseconds after now, capped at the largest
+// expiry the store keeps
+func addMillis(now uint64, delta uint64) uint64 {
+	if delta > hllstore.MaxExpiry-now {
+		return hllstore.MaxExpiry
+	}
+	return now + delta
+}
+
+// Returns true if the log with the earliest expiry expired at the unix time
+// now. Must be called with the lock of the map held
+func (hm *hllMap) expiryDue(now uint64) bool {
+	return len(hm.expiries) > 0 && hm.expiries[0].expired(now)
+}
+
+// Must be called after the expiry heap changed, with the write lock of the map
+// held
+func (hm *hllMap) setNextExpiry() {
+	next := uint64(0)
+	if len(hm.expiries) > 0 {
+		next = hm.expiries[0].expiry
+	}
+	atomic.StoreUint64(&hm.nextexpiry, next)
+}
+
+// Sets the unix time expiry of the log, 0 for no expiry. Must be called with
+// the write lock of the map held
+func (hm *hllMap) setLogExpiry(hlog *hyperlog, expiry uint64) {
+	hlog.expiry = expiry
+	switch {
+	case expiry == 0 && hlog.expidx >= 0:
+		heap.Remove(&hm.expiries, hlog.expidx)
+	case expiry == 0:
+	case hlog.expidx >= 0:
+		heap.Fix(&hm.expiries, hlog.expidx)
+	default:
+		heap.Push(&hm.expiries, hlog)
+	}
+	hm.setNextExpiry()
+}
+
+// Removes the log from the expiry heap, must be called with the write lock of
+// the map held
+func (hm *hllMap) unsetLogExpiry(hlog *hyperlog) {
+	if hlog.expidx >= 0 {
+		heap.Remove(&hm.expiries, hlog.expidx)
+		hm.setNextExpiry()
+	}
+}
+
+// Removes the expired log from the map and the expiry heap and deletes it
+// from the store. Must be called with the write lock of the map held
+func (hm *hllMap) removeExpired(hlog *hyperlog) {
+	// logged before the changes of a recreated log, not waited for even in
+	// ack mode as the map is locked, a later wait covers it
+	hlog.wallock.Lock()
+	hm.removeLog(hlog, walDelete(hlog.key))
+	hlog.wallock.Unlock()
+	hllogs.Log.Debugf("Removed expired key %s", hlog.key)
+}
+
+// Removes the log from the map and the expiry heap, marks it deleted and
+// appends the record, if not nil, to the write ahead log after the records of
+// the changes of the log. Queues the delete of the log from the store, it is
+// not waited for. Returns the sequence number of the record. Must be called
+// with the write lock of the map and the wallock of the log held
+func (hm *hllMap) removeLog(hlog *hyperlog, record []byte) uint64 {
+	delete(hm.logm, hlog.key)
+	hm.unsetLogExpiry(hlog)
+	// the updates holding the log are rejected from now on
+	hlog.lock.Lock()
+	atomic.StoreUint32(&hlog.deleted, 1)
+	hlog.lock.Unlock()
+	var seq uint64
+	if record != nil && hm.hlc.wal != nil {
+		seq = hm.hlc.wal.Append(record)
+	}
+	// queued like the updates, so that the delete reaches the store before
+	// the updates of a log added afterwards for the key
+	if hm.hlc.store != nil && atomic.AddInt32(&hlog.updated, 1) == 1 {
+		hm.hlc.enqueueStoreUpd(hm.slot, hlog)
+	}
+	return seq
+}
+
+// Removes the expired logs, returns the number of removed logs. The maps
+// without an expired log aren't locked
+func (hc *HllContainer) doCleanup() int {
+	now := nowMillis()
+	removed := 0
+	for _, hm := range hc.hllmaps {
+		next := atomic.LoadUint64(&hm.nextexpiry)
+		due := next > 0 && next <= now
+		for due {
+			select {
+			case <-hc.shutdown:
+				return removed
+			default:
+			}
+			hm.mutex.Lock()
+			for i := 0; i < cLEANUPBATCH && hm.expiryDue(now); i++ {
+				hm.removeExpired(hm.expiries[0])
+				removed++
+			}
+			due = hm.expiryDue(now)
+			hm.mutex.Unlock()
+		}
+	}
+	if removed > 0 {
+		hllogs.Log.Debugf("Removed %d expired keys", removed)
+	}
+	return removed
+}
+
+func (hc *HllContainer) cleanup() {
+	defer hc.workers.Done()
+	for {
+		select {
+		case _ = <-hc.ticker.C:
+			hc.doCleanup()
+		case _ = <-hc.shutdown:
+			return
+		}
+	}
+}
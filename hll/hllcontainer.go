@@ -1,35 +1,50 @@
 package hll
 
 import (
+	"bytes"
 	"container/list"
 	"errors"
 	"github.com/nipuntalukdar/hllserver/hllogs"
 	"github.com/nipuntalukdar/hllserver/hllstore"
-	"github.com/nipuntalukdar/hllserver/hutil"
-	"sort"
+	"math"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
 const (
-	mAXSLOTS  = 2048
-	mIINSLOTS = 4
-	sEED      = 32
-	nUPDL     = 8
-	eXPBK     = 0xffffffffffffffc0
+	mAXSLOTS        = 2048
+	mIINSLOTS       = 4
+	sEED            = 32
+	nUPDL           = 8
+	sHUTDOWNTIMEOUT = 30 * time.Second
+	// how often the logs sent to the store are committed
+	sTOREFLUSHINTERVAL = 1 * time.Second
 )
 
-type hllMap struct {
-	mutex *sync.RWMutex
-	logm  map[string]*hyperlog
-	hlc   *HllContainer
-	slot  uint32
-}
+var ErrIncompatibleLogs = errors.New("Logs with different algorithm, precision or salt")
+var ErrInvalidSketch = errors.New("Error in decoding sketch")
+var ErrLogNotExists = errors.New("Log doesn't exist")
+var ErrLogExists = errors.New("Log already exists")
+var ErrNotRedisLog = errors.New("Log doesn't use the redis algorithm")
+var ErrHashWidth = errors.New("Hash is wider than the hash bits of the log")
+var ErrInvalidSalt = errors.New("Salt must be 16 bytes")
+var ErrInvalidLogParams = errors.New("Invalid precision or algorithm")
+var ErrSaltMismatch = errors.New("Log exists with a different salt")
+var ErrSaltedLog = errors.New("Log hashes items with a secret salt")
+var ErrStoreOverloaded = errors.New("Too many updates pending for the store")
+var ErrNotDurable = errors.New("Change not durable in write ahead log")
 
-type expm struct {
-	part uint32
-	log  *hyperlog
+type hllMap struct {
+	mutex    *sync.RWMutex
+	logm     map[string]*hyperlog
+	expiries expiryHeap
+	// earliest expiry in the heap, 0 if it is empty. Read by the cleanup
+	// without the lock of the map
+	nextexpiry uint64
+	hlc        *HllContainer
+	slot       uint32
 }
 
 type updLogs struct {
@@ -39,8 +54,6 @@ type updLogs struct {
 
 type HllContainer struct {
 	hllmaps      []*hllMap
-	expirym      map[uint64]map[string]*expm
-	exmutex      *sync.RWMutex
 	hslot        uint32
 	ticker       *time.Ticker
 	shutdown     chan bool
@@ -48,10 +61,22 @@ type HllContainer struct {
 	updates      []*updLogs
 	updchan      chan *hyperlog
 	delete_first []string
-}
-
-func newExpm(part uint32, log *hyperlog) *expm {
-	return &expm{part, log}
+	// shutdown is closed once, the background goroutines are counted in workers
+	shutonce *sync.Once
+	workers  *sync.WaitGroup
+	// logs queued for store update and the limit of them, 0 for no limit
+	pending    atomic.Int64
+	maxpending atomic.Int64
+	// salts of the namespaces, the namespace of a key is the part before ':'
+	salts    map[string][]byte
+	saltlock *sync.RWMutex
+	// write ahead log of the changes, nil if disabled
+	wal      *hllstore.Wal
+	walack   bool
+	ckptchan chan chan error
+	// logs sent to the store since its last flush, used only by the store
+	// updates goroutine
+	unflushed []*hyperlog
 }
 
 func newUpdLogs() *updLogs {
@@ -64,6 +89,19 @@ func newHllMap(hlc *HllContainer, slot uint32) *hllMap {
 }
 
 func NewHllContainer(slots uint32, store hllstore.HllStore) *HllContainer {
+	return NewHllContainerWithWal(slots, store, nil, false, 0)
+}
+
+// The changes of the logs are also appended to the write ahead log, which is
+// replayed into the store before restore. With ack the updates return only
+// after their changes are durable in the write ahead log. Every checkpoint
+// interval the changed logs are written to the store and the write ahead log
+// is truncated. The write ahead log is used only with a store
+func NewHllContainerWithWal(slots uint32, store hllstore.HllStore, wal *hllstore.Wal,
+	ack bool, checkpoint time.Duration) *HllContainer {
+	if store == nil {
+		wal = nil
+	}
 	if slots < mIINSLOTS {
 		slots = mIINSLOTS
 	}
@@ -79,8 +117,7 @@ func NewHllContainer(slots uint32, store hllstore.HllStore) *HllContainer {
 		slots = powered
 	}
 	hllmaps := make([]*hllMap, slots)
-	exmutex := &sync.RWMutex{}
-	ticker := time.NewTicker(60 * time.Second)
+	ticker := time.NewTicker(cLEANUPINTERVAL)
 	var updls []*updLogs
 	if store != nil {
 		updls = make([]*updLogs, nUPDL)
@@ -90,10 +127,12 @@ func NewHllContainer(slots uint32, store hllstore.HllStore) *HllContainer {
 			i++
 		}
 	}
-	hlc := &HllContainer{hllmaps: hllmaps, expirym: make(map[uint64]map[string]*expm),
-		exmutex: exmutex, hslot: slots - 1, ticker: ticker,
-		shutdown: make(chan bool), store: store, updates: updls,
-		updchan: make(chan *hyperlog, 20480), delete_first: []string{}}
+	hlc := &HllContainer{hllmaps: hllmaps, hslot: slots - 1, ticker: ticker,
+		shutdown: make(chan bool), shutonce: &sync.Once{}, workers: &sync.WaitGroup{},
+		store: store, updates: updls,
+		updchan: make(chan *hyperlog, 20480), delete_first: []string{},
+		salts: make(map[string][]byte), saltlock: &sync.RWMutex{},
+		wal: wal, walack: ack, ckptchan: make(chan chan error)}
 
 	i := uint32(0)
 	for i < slots {
@@ -101,20 +140,34 @@ func NewHllContainer(slots uint32, store hllstore.HllStore) *HllContainer {
 		i++
 	}
 	if store != nil {
+		if wal != nil {
+			if err := hlc.replayWal(); err != nil {
+				hllogs.Log.Fatalf("Failed to replay write ahead log: %s", err)
+			}
+		}
 		// First restore from store
 		hlc.restore()
 		i = 0
 		for i < 8 {
+			hlc.workers.Add(1)
 			go hlc.savechanges(hlc.updates[i])
 			i++
 		}
+		hlc.workers.Add(1)
 		go hlc.storeUpdates()
+		if wal != nil {
+			hlc.workers.Add(1)
+			go hlc.checkpoints(checkpoint)
+		}
 	}
 
+	hlc.workers.Add(1)
 	go hlc.cleanup()
 	if store != nil && len(hlc.delete_first) > 0 {
 		for _, key := range hlc.delete_first {
-			hlc.store.Delete(key)
+			if err := hlc.store.Delete(key); err != nil {
+				hllogs.Log.Errorf("Failed to delete expired key %s: %s", key, err)
+			}
 		}
 	}
 	hlc.delete_first = nil
@@ -122,22 +175,122 @@ func NewHllContainer(slots uint32, store hllstore.HllStore) *HllContainer {
 	return hlc
 }
 
-func (hc *HllContainer) AddLog(key string, entry []byte, expiry uint64) {
+// Adds the entry to the log, a nil entry only creates the log. Returns the error
+// if the change can't be made durable in the write ahead log
+func (hc *HllContainer) AddLog(key string, entry []byte, expiry uint64, precision uint8,
+	algo uint8) error {
+	if !validLogParams(precision, algo) {
+		return ErrInvalidLogParams
+	}
 	slot := murmur3_32([]byte(key), sEED) & hc.hslot
-	hm := hc.hllmaps[slot]
-	hlog := hm.getOrAddLog(key, expiry)
-	if entry != nil {
-		entryh := murmur3_32(entry, sEED)
-		newval, updated := hlog.addhash(entryh)
-		if hc.store != nil {
-			if updated && newval == 1 {
-				hc.enqueueStoreUpd(slot, hlog)
+	_, err := hc.hllmaps[slot].updateLog(key, expiry, precision, algo, nil, wALSLOTS,
+		func(hlog *hyperlog) ([]uint32, bool, bool, error) {
+			if entry == nil {
+				return nil, false, true, nil
 			}
-		}
+			return noErr(hlog.addhashes([]uint64{hlog.hash(entry)}))
+		})
+	return err
+}
+
+// Adds the log hashing its items with SipHash keyed by the secret salt instead
+// of the public murmur3 seed, so that items can't be crafted to skew the
+// cardinality. Logs with the same salt can be merged. A nil salt uses the salt
+// of the namespace of the key if any
+func (hc *HllContainer) AddKeyedLog(key string, expiry uint64, precision uint8, algo uint8,
+	salt []byte) error {
+	return hc.addKeyedLog(key, expiry, precision, algo, salt, false)
+}
+
+// Creates the log like AddKeyedLog, but returns ErrLogExists if the log exists
+func (hc *HllContainer) CreateLog(key string, expiry uint64, precision uint8, algo uint8,
+	salt []byte) error {
+	return hc.addKeyedLog(key, expiry, precision, algo, salt, true)
+}
+
+func (hc *HllContainer) addKeyedLog(key string, expiry uint64, precision uint8, algo uint8,
+	salt []byte, create bool) error {
+	// redis logs must hash like redis
+	if !validLogParams(precision, algo) || (salt != nil && algo == REDIS) {
+		return ErrInvalidLogParams
+	}
+	if salt != nil && len(salt) != sALTLEN {
+		return ErrInvalidSalt
+	}
+	if salt != nil {
+		salt = append([]byte(nil), salt...)
+	}
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog, created := hc.hllmaps[slot].addLog(key, expiry, precision, algo, salt)
+	if create && !created {
+		return ErrLogExists
+	}
+	if salt != nil && !bytes.Equal(hlog.salt, salt) {
+		return ErrSaltMismatch
+	}
+	if hc.wal != nil {
+		return hc.walLog(walSlots(wALSLOTS, hlog, nil))
+	}
+	return nil
+}
+
+// Sets the salt of the logs added afterwards in the namespace, existing logs
+// keep their salt. A nil salt removes the salt of the namespace
+func (hc *HllContainer) SetNamespaceSalt(namespace string, salt []byte) error {
+	if salt != nil && len(salt) != sALTLEN {
+		return ErrInvalidSalt
+	}
+	hc.saltlock.Lock()
+	defer hc.saltlock.Unlock()
+	if salt == nil {
+		delete(hc.salts, namespace)
+	} else {
+		hc.salts[namespace] = append([]byte(nil), salt...)
+	}
+	return nil
+}
+
+func (hc *HllContainer) namespaceSalt(key string) []byte {
+	i := strings.IndexByte(key, ':')
+	if i < 0 {
+		return nil
 	}
+	hc.saltlock.RLock()
+	defer hc.saltlock.RUnlock()
+	return hc.salts[key[:i]]
 }
 
-func (hc *HllContainer) UpdateExpiry(key string, expiry uint64) bool {
+// Expires the log in expiry seconds, returns false if the log doesn't exist
+func (hc *HllContainer) UpdateExpiry(key string, expiry uint64) (bool, error) {
+	return hc.PExpire(key, secondsToMillis(expiry))
+}
+
+// Expires the log in expiry milliseconds, returns false if the log doesn't
+// exist
+func (hc *HllContainer) PExpire(key string, expiry uint64) (bool, error) {
+	return hc.PExpireAt(key, addMillis(nowMillis(), expiry))
+}
+
+// Sets the expiry of the log to the unix time expiry, returns false if the log
+// doesn't exist or expiry is 0. An expiry in the past expires the log at once
+func (hc *HllContainer) ExpireAt(key string, expiry uint64) (bool, error) {
+	return hc.PExpireAt(key, secondsToMillis(expiry))
+}
+
+// Like ExpireAt with the unix time expiry in milliseconds
+func (hc *HllContainer) PExpireAt(key string, expiry uint64) (bool, error) {
+	expiry = min(expiry, hllstore.MaxExpiry)
+	if expiry == 0 || !hc.setExpiry(key, expiry) {
+		return false, nil
+	}
+	if hc.wal != nil {
+		return true, hc.walLog(walExpiry(key, expiry))
+	}
+	return true, nil
+}
+
+// Sets the expiry of the log to the unix time expiry in milliseconds
+func (hc *HllContainer) setExpiry(key string, expiry uint64) bool {
 	slot := murmur3_32([]byte(key), sEED) & hc.hslot
 	ret := true
 	hm := hc.hllmaps[slot]
@@ -153,8 +306,8 @@ func (hc *HllContainer) UpdateExpiry(key string, expiry uint64) bool {
 
 	hm.mutex.Lock()
 	defer hm.mutex.Unlock()
-	hlog, ok = hm.logm[key]
-	if !ok {
+	hlog = hm.liveLog(key)
+	if hlog == nil {
 		return false
 	}
 	hlog.lock.Lock()
@@ -162,41 +315,104 @@ func (hc *HllContainer) UpdateExpiry(key string, expiry uint64) bool {
 	if hlog.deleted > 0 {
 		return false
 	}
-	oldexpiry := hlog.expiry
-	expiry += uint64(time.Now().Unix())
-	var expbkt uint64 = 0
-	if oldexpiry > 0 {
-		expbkt = oldexpiry&eXPBK + 64
+	hm.setLogExpiry(hlog, expiry)
+	hlog.touch()
+	newval := atomic.AddInt32(&hlog.updated, 1)
+	if newval == 1 && hc.store != nil {
+		hc.enqueueStoreUpd(slot, hlog)
 	}
-	newexpbkt := expiry&eXPBK + 64
-	if expbkt == newexpbkt {
-		hlog.expiry = expiry
+	return true
+}
+
+// Adds the entries to the log, returns true if any slot of the log changed
+func (hc *HllContainer) AddMLog(key string, entry [][]byte, expiry uint64) (bool, error) {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hashes := make([]uint64, len(entry))
+	changes, err := hc.hllmaps[slot].updateLog(key, expiry, 0, CLASSIC, nil, wALSLOTS,
+		func(hlog *hyperlog) ([]uint32, bool, bool, error) {
+			for i, e := range entry {
+				hashes[i] = hlog.hash(e)
+			}
+			return noErr(hlog.addhashes(hashes))
+		})
+	return len(changes) > 0, err
+}
+
+// Adds items hashed by the client, the server doesn't hash them again. The
+// hashes must fit in the hash bits of the log, 32 bits for the classic
+// algorithm and 64 bits for the others. A missing log is created with the
+// default precision and the classic algorithm. Logs hashing with a secret salt
+// don't accept hashes. Returns whether any slot changed
+func (hc *HllContainer) AddHashes(key string, hashes []uint64, expiry uint64) (bool, error) {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hm := hc.hllmaps[slot]
+	algo := CLASSIC
+	if hlog := hm.getLog(key); hlog != nil {
+		algo = hlog.algo
+		if hlog.salt != nil {
+			return false, ErrSaltedLog
+		}
+	} else if hc.namespaceSalt(key) != nil {
+		return false, ErrSaltedLog
+	}
+	if !validHashes(algo, hashes) {
+		return false, ErrHashWidth
+	}
+	changes, err := hm.updateLog(key, expiry, 0, CLASSIC, nil, wALSLOTS,
+		func(hlog *hyperlog) ([]uint32, bool, bool, error) {
+			// the log may have been added meanwhile with another algorithm or
+			// a salt
+			if hlog.salt != nil {
+				return nil, false, false, ErrSaltedLog
+			}
+			if hlog.algo != algo && !validHashes(hlog.algo, hashes) {
+				return nil, false, false, ErrHashWidth
+			}
+			return noErr(hlog.addhashes(hashes))
+		})
+	return len(changes) > 0, err
+}
+
+func validHashes(algo uint8, hashes []uint64) bool {
+	if algo != CLASSIC {
 		return true
 	}
-	hm.hlc.exmutex.Lock()
-	defer hm.hlc.exmutex.Unlock()
-	var exp *expm
-	em, ok := hm.hlc.expirym[expbkt]
-	if ok {
-		hllogs.Log.Debugf("Old expiry bucket %d for key: %s", expbkt, key)
-		exp, ok = em[key]
-		if ok {
-			delete(em, key)
-		} else {
-			exp = newExpm(hm.slot, hlog)
+	for _, h := range hashes {
+		if h > math.MaxUint32 {
+			return false
 		}
-	} else {
-		hllogs.Log.Debugf("Old expiry bucket %d not found for key: %s", expbkt, key)
-		exp = newExpm(hm.slot, hlog)
 	}
-	hllogs.Log.Debugf("Adding key: %s to new expiry bucket %d", key, newexpbkt)
-	em, ok = hm.hlc.expirym[newexpbkt]
-	if !ok {
-		em = make(map[string]*expm)
-		hm.hlc.expirym[newexpbkt] = em
+	return true
+}
+
+// Removes the expiry of the log, returns false if the log doesn't exist or
+// has no expiry
+func (hc *HllContainer) Persist(key string) (bool, error) {
+	if !hc.persist(key) {
+		return false, nil
+	}
+	if hc.wal != nil {
+		return true, hc.walLog(walExpiry(key, 0))
 	}
-	hlog.expiry = expiry
-	em[key] = exp
+	return true, nil
+}
+
+func (hc *HllContainer) persist(key string) bool {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hm := hc.hllmaps[slot]
+	hm.mutex.Lock()
+	defer hm.mutex.Unlock()
+	hlog := hm.liveLog(key)
+	if hlog == nil {
+		return false
+	}
+	hlog.lock.Lock()
+	defer hlog.lock.Unlock()
+	if hlog.deleted > 0 || hlog.expiry == 0 {
+		return false
+	}
+	hm.setLogExpiry(hlog, 0)
+	hlog.touch()
 
 	newval := atomic.AddInt32(&hlog.updated, 1)
 	if newval == 1 && hc.store != nil {
@@ -205,89 +421,481 @@ func (hc *HllContainer) UpdateExpiry(key string, expiry uint64) bool {
 	return true
 }
 
-func (hc *HllContainer) AddMLog(key string, entry [][]byte, expiry uint64) {
+// Returns the remaining time to live of the log in seconds, rounded up, -1 if
+// the log has no expiry and -2 if the log doesn't exist
+func (hc *HllContainer) TTL(key string) int64 {
+	expiry, ok := hc.getExpiry(key)
+	if !ok {
+		return -2
+	}
+	return remainingTTL(expiry, nowMillis())
+}
+
+// Like TTL in milliseconds
+func (hc *HllContainer) PTTL(key string) int64 {
+	expiry, ok := hc.getExpiry(key)
+	if !ok {
+		return -2
+	}
+	return remainingPTTL(expiry, nowMillis())
+}
+
+// Returns the unix time expiry of the log, rounded up to seconds, 0 if the log
+// has no expiry. Returns false if the log doesn't exist
+func (hc *HllContainer) GetExpiry(key string) (uint64, bool) {
+	expiry, ok := hc.getExpiry(key)
+	return millisToSeconds(expiry), ok
+}
+
+// Returns the unix time expiry of the log in milliseconds
+func (hc *HllContainer) getExpiry(key string) (uint64, bool) {
 	slot := murmur3_32([]byte(key), sEED) & hc.hslot
-	hm := hc.hllmaps[slot]
-	hlog := hm.getOrAddLog(key, expiry)
-	enqueue := false
-	for _, e := range entry {
-		entryh := murmur3_32(e, sEED)
-		newval, updated := hlog.addhash(entryh)
-		if newval == 1 && updated {
-			enqueue = true
-		}
+	hlog := hc.hllmaps[slot].getLog(key)
+	if hlog == nil {
+		return 0, false
 	}
-	if enqueue && hc.store != nil {
-		hc.enqueueStoreUpd(slot, hlog)
+	hlog.lock.RLock()
+	defer hlog.lock.RUnlock()
+	if hlog.deleted > 0 {
+		return 0, false
 	}
+	return hlog.expiry, true
+}
+
+type LogInfo struct {
+	Key       string
+	Algorithm uint8
+	Precision uint8
+	// whether the log hashes its items with a secret salt
+	Salted bool
+	// unix time expiry, rounded up to seconds, 0 if the log has no expiry
+	Expiry       uint64
+	NonZeroSlots uint32
+	// changes not yet written to the store
+	PendingUpdates int32
+	// size of the log as written to the store
+	SerializedSize int
+	// whether the store has committed the latest changes of the log, false
+	// without a store and for a log never written to it
+	Persisted bool
+	// creation and last modification unix times, 0 if unknown
+	Ctime uint64
+	Mtime uint64
 }
 
-func (hm *hllMap) getOrAddLog(key string, expiry uint64) *hyperlog {
+// Returns the metadata of the log, ErrLogNotExists if the log doesn't exist
+func (hc *HllContainer) GetLogInfo(key string) (LogInfo, error) {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog := hc.hllmaps[slot].getLog(key)
+	if hlog == nil {
+		return LogInfo{}, ErrLogNotExists
+	}
+	size := len(hlog.serializeRecord())
+	hlog.lock.RLock()
+	defer hlog.lock.RUnlock()
+	if hlog.deleted > 0 {
+		return LogInfo{}, ErrLogNotExists
+	}
+	pending := hlog.getUpdCount()
+	persisted := atomic.LoadUint32(&hlog.stored) == 1 && pending == 0
+	return LogInfo{Key: key, Algorithm: hlog.algo, Precision: hlog.precision,
+		Salted: hlog.salt != nil, Expiry: millisToSeconds(hlog.expiry),
+		NonZeroSlots: atomic.LoadUint32(&hlog.numnonzeroslot), PendingUpdates: pending,
+		SerializedSize: size, Persisted: persisted, Ctime: hlog.ctime,
+		Mtime: atomic.LoadUint64(&hlog.mtime)}, nil
+}
+
+func (hc *HllContainer) Exists(key string) bool {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog := hc.hllmaps[slot].getLog(key)
+	return hlog != nil && atomic.LoadUint32(&hlog.deleted) == 0
+}
+
+// Returns the number of logs and the number of logs with an expiry
+func (hc *HllContainer) NumLogs() (uint64, uint64) {
+	var numlogs, numexpiry uint64
+	for _, hm := range hc.hllmaps {
+		hm.mutex.RLock()
+		numlogs += uint64(len(hm.logm))
+		numexpiry += uint64(len(hm.expiries))
+		hm.mutex.RUnlock()
+	}
+	return numlogs, numexpiry
+}
+
+// Returns the log of the key, adding it if it doesn't exist, and whether it
+// was created. A new log is created with the salt, with a nil salt it gets the
+// salt of its namespace if any. Logs using the REDIS algorithm are never salted
+func (hm *hllMap) addLog(key string, expiry uint64, precision uint8,
+	algo uint8, salt []byte) (*hyperlog, bool) {
+	created := false
+	now := nowMillis()
 	hm.mutex.RLock()
 	hlog, ok := hm.logm[key]
+	ok = ok && !hlog.expired(now)
 	hm.mutex.RUnlock()
 	if !ok {
 		hm.mutex.Lock()
-		hlog, ok = hm.logm[key]
-		if !ok {
+		// an expired log is recreated, not updated
+		hlog = hm.liveLog(key)
+		if hlog == nil {
 			// we are adding a new log key
-			hlog = newHyperLog(key, expiry)
+			hlog = newHyperLog(key, expiry, precision, algo)
+			if salt == nil {
+				salt = hm.hlc.namespaceSalt(key)
+			}
+			if algo != REDIS {
+				hlog.salt = salt
+			}
 			if expiry > 0 {
-				expiry += uint64(time.Now().Unix())
-				hlog.expiry = expiry
-				exp := newExpm(hm.slot, hlog)
-				expbkt := expiry&eXPBK + 64
-				hm.hlc.exmutex.Lock()
-				em, ok := hm.hlc.expirym[expbkt]
-				if !ok {
-					em = make(map[string]*expm)
-					hm.hlc.expirym[expbkt] = em
-				}
-				em[key] = exp
-				hm.hlc.exmutex.Unlock()
-				hllogs.Log.Debugf("Putting key %s, in expbkt: %d", key, expbkt)
+				hm.setLogExpiry(hlog, addMillis(now, secondsToMillis(expiry)))
 			}
 			hm.logm[key] = hlog
+			created = true
 		}
 		hm.mutex.Unlock()
 	}
-	return hlog
+	return hlog, created
+}
+
+// Applies update to the log of the key, created like addLog if it doesn't
+// exist. update returns the changed slots as index<<8 | value entries, true if
+// the log needs to be enqueued for store update and false if the log was
+// removed meanwhile, e.g. by a rename, update is then applied again to the log
+// which replaced it. The changes, and the creation of the log, are recorded in
+// the write ahead log with op, a wALREPLACE is always recorded. Returns the
+// changed slots and the error of update or of the write ahead log
+func (hm *hllMap) updateLog(key string, expiry uint64, precision uint8, algo uint8,
+	salt []byte, op byte,
+	update func(hlog *hyperlog) ([]uint32, bool, bool, error)) ([]uint32, error) {
+	hc := hm.hlc
+	for {
+		hlog, created := hm.addLog(key, expiry, precision, algo, salt)
+		if hc.wal != nil {
+			hlog.wallock.Lock()
+		}
+		changes, enqueue, live, err := update(hlog)
+		record := hc.wal != nil && err == nil && live &&
+			(created || len(changes) > 0 || op == wALREPLACE)
+		// in ack mode an update without changes waits too, the records of
+		// the changes which made it a no-op are appended already
+		wait := record || (hc.wal != nil && hc.walack && err == nil && live)
+		var seq uint64
+		if record {
+			seq = hc.wal.Append(walSlots(op, hlog, changes))
+		} else if wait {
+			seq = hc.wal.Seq()
+		}
+		if hc.wal != nil {
+			hlog.wallock.Unlock()
+		}
+		if enqueue && hc.store != nil {
+			hc.enqueueStoreUpd(hm.slot, hlog)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !live {
+			continue
+		}
+		if wait {
+			return changes, hc.walWait(seq)
+		}
+		return changes, nil
+	}
+}
+
+// Adds a nil error to the results of addhashes and mergeentries for updateLog
+func noErr(changes []uint32, enqueue bool, live bool) ([]uint32, bool, bool, error) {
+	return changes, enqueue, live, nil
+}
+
+// Returns the log which replaced the removed log in the map, nil if there is
+// none
+func (hm *hllMap) newerLog(hlog *hyperlog) *hyperlog {
+	hm.mutex.RLock()
+	defer hm.mutex.RUnlock()
+	newer, ok := hm.logm[hlog.key]
+	if !ok || newer == hlog {
+		return nil
+	}
+	return newer
 }
 
+// Returns the log of the key, nil if it doesn't exist or expired
 func (hm *hllMap) getLog(key string) *hyperlog {
 	hm.mutex.RLock()
 	hlog, ok := hm.logm[key]
+	expired := ok && hlog.expired(nowMillis())
 	hm.mutex.RUnlock()
 	if !ok {
 		return nil
 	}
+	if expired {
+		hm.mutex.Lock()
+		hlog = hm.liveLog(key)
+		hm.mutex.Unlock()
+	}
 	return hlog
 }
 
-func (hc *HllContainer) DelLog(key string) bool {
+// Returns the log of the key, nil if it doesn't exist. An expired log is
+// removed, it is not waited for the cleanup. Must be called with the write
+// lock of the map held
+func (hm *hllMap) liveLog(key string) *hyperlog {
+	hlog, ok := hm.logm[key]
+	if !ok {
+		return nil
+	}
+	if hlog.expired(nowMillis()) {
+		hm.removeExpired(hlog)
+		return nil
+	}
+	return hlog
+}
+
+// Deletes the log, returns false if the log doesn't exist and the error if the
+// delete can't be made durable in the write ahead log
+func (hc *HllContainer) DelLog(key string) (bool, error) {
 	slot := murmur3_32([]byte(key), sEED) & hc.hslot
 	hm := hc.hllmaps[slot]
-	if hm.getLog(key) != nil {
-		hm.mutex.Lock()
-		hlog, ok := hm.logm[key]
-		if ok {
-			delete(hm.logm, key)
-		}
+	if hm.getLog(key) == nil {
+		return false, nil
+	}
+	hm.mutex.Lock()
+	hlog := hm.liveLog(key)
+	if hlog == nil {
 		hm.mutex.Unlock()
-		if ok && hc.store != nil {
-			hlog.delwait.Add(1)
-			hlog.delwaiter += 1
-			atomic.StoreUint32(&hlog.deleted, 1)
-			newval := atomic.AddInt32(&hlog.updated, 1)
-			if newval == 1 {
-				hc.enqueueStoreUpd(slot, hlog)
+		return false, nil
+	}
+	if hc.store != nil {
+		hlog.delwait.Add(1)
+		hlog.delwaiter += 1
+	}
+	// logged before the store delete, so that a replay can't bring back the
+	// log from its older changes, and after the changes of the log
+	hlog.wallock.Lock()
+	seq := hm.removeLog(hlog, walDelete(key))
+	hlog.wallock.Unlock()
+	hm.mutex.Unlock()
+	var err error
+	if hc.wal != nil {
+		err = hc.walWait(seq)
+	}
+	if hc.store != nil {
+		// Wait for delete actually applies to store
+		hlog.delwait.Wait()
+	}
+	return true, err
+}
+
+// Returns the existing logs for the keys, non-existing keys are skipped
+func (hc *HllContainer) getLogs(keys []string, skip string) ([]*hyperlog, error) {
+	var hlogs []*hyperlog
+	for _, key := range keys {
+		if key == skip {
+			continue
+		}
+		slot := murmur3_32([]byte(key), sEED) & hc.hslot
+		hlog := hc.hllmaps[slot].getLog(key)
+		if hlog != nil && atomic.LoadUint32(&hlog.deleted) == 0 {
+			if len(hlogs) > 0 && !hlogs[0].compatible(hlog) {
+				return nil, ErrIncompatibleLogs
 			}
-			// Wait for delete actually applies to store
-			hlog.delwait.Wait()
-			return true
+			hlogs = append(hlogs, hlog)
 		}
 	}
-	return true
+	return hlogs, nil
+}
+
+// Merges the source logs into dest, creating dest if it doesn't exist
+func (hc *HllContainer) Merge(dest string, sources []string) error {
+	// non-existing source logs are treated as empty
+	srclogs, err := hc.getLogs(sources, dest)
+	if err != nil {
+		return err
+	}
+	precision := uint8(0)
+	algo := CLASSIC
+	var salt []byte
+	if len(srclogs) > 0 {
+		precision = srclogs[0].precision
+		algo = srclogs[0].algo
+		salt = srclogs[0].salt
+	}
+	slot := murmur3_32([]byte(dest), sEED) & hc.hslot
+	_, err = hc.hllmaps[slot].updateLog(dest, 0, precision, algo, salt, wALSLOTS,
+		func(dlog *hyperlog) ([]uint32, bool, bool, error) {
+			if len(srclogs) > 0 && !dlog.compatible(srclogs[0]) {
+				return nil, false, false, ErrIncompatibleLogs
+			}
+			var changes []uint32
+			enqueue := false
+			for _, hlog := range srclogs {
+				merged, enq, live := dlog.mergeentries(hlog.slotentries())
+				enqueue = enqueue || enq
+				if !live {
+					return nil, enqueue, false, nil
+				}
+				changes = append(changes, merged...)
+			}
+			return changes, enqueue, true, nil
+		})
+	return err
+}
+
+// Returns the cardinality of the union of the logs without modifying any of them
+func (hc *HllContainer) GetUnionCardinality(keys []string) (uint64, error) {
+	est, err := hc.GetUnionEstimate(keys, nil)
+	return est.Cardinality, err
+}
+
+func (hc *HllContainer) GetUnionEstimate(keys []string, est Estimator) (Estimate, error) {
+	hlogs, err := hc.getLogs(keys, "")
+	if err != nil {
+		return Estimate{}, err
+	}
+	if len(hlogs) == 0 {
+		return emptyEstimate(est), nil
+	}
+	union := newHyperLog("", 0, hlogs[0].precision, hlogs[0].algo)
+	for _, hlog := range hlogs {
+		union.mergefrom(hlog)
+	}
+	return union.estimate(est), nil
+}
+
+// Estimates |A|, |B| and |A U B| from a snapshot of the logs. Also returns the
+// relative standard error of the estimates
+func (hc *HllContainer) estimateSets(keya string, keyb string) ([3]float64, float64, error) {
+	var cards [3]float64
+	hlogs := make([]*hyperlog, 2)
+	for i, key := range []string{keya, keyb} {
+		slot := murmur3_32([]byte(key), sEED) & hc.hslot
+		hlog := hc.hllmaps[slot].getLog(key)
+		if hlog != nil && atomic.LoadUint32(&hlog.deleted) == 0 {
+			hlogs[i] = hlog
+		}
+	}
+	if hlogs[0] == nil && hlogs[1] == nil {
+		return cards, 0, nil
+	}
+	if hlogs[0] != nil && hlogs[1] != nil && !hlogs[0].compatible(hlogs[1]) {
+		return cards, 0, ErrIncompatibleLogs
+	}
+	proto := hlogs[0]
+	if proto == nil {
+		proto = hlogs[1]
+	}
+	union := newHyperLog("", 0, proto.precision, proto.algo)
+	for i, hlog := range hlogs {
+		if hlog == nil {
+			continue
+		}
+		snap := newHyperLog("", 0, proto.precision, proto.algo)
+		snap.mergefrom(hlog)
+		union.mergefrom(snap)
+		cards[i] = float64(snap.count_cardinality())
+	}
+	cards[2] = float64(union.count_cardinality())
+	return cards, union.stderror(), nil
+}
+
+// Estimates |A ∩ B| as |A| + |B| - |A U B|. Returns the estimate and an error
+// bound of about one standard deviation
+func (hc *HllContainer) GetIntersectionCardinality(keya string, keyb string) (uint64, uint64, error) {
+	cards, stderr, err := hc.estimateSets(keya, keyb)
+	if err != nil {
+		return 0, 0, err
+	}
+	inter := cards[0] + cards[1] - cards[2]
+	inter = math.Max(0, math.Min(inter, math.Min(cards[0], cards[1])))
+	bound := stderr * (cards[0] + cards[1] + cards[2])
+	return uint64(inter + 0.5), uint64(math.Ceil(bound)), nil
+}
+
+// Estimates |A \ B| as |A U B| - |B|. Returns the estimate and an error bound
+// of about one standard deviation
+func (hc *HllContainer) GetDifferenceCardinality(keya string, keyb string) (uint64, uint64, error) {
+	cards, stderr, err := hc.estimateSets(keya, keyb)
+	if err != nil {
+		return 0, 0, err
+	}
+	diff := math.Max(0, math.Min(cards[2]-cards[1], cards[0]))
+	bound := stderr * (cards[1] + cards[2])
+	return uint64(diff + 0.5), uint64(math.Ceil(bound)), nil
+}
+
+// Returns the serialized sketch of the log, false if the log doesn't exist
+func (hc *HllContainer) ExportLog(key string) ([]byte, bool) {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog := hc.hllmaps[slot].getLog(key)
+	if hlog == nil || atomic.LoadUint32(&hlog.deleted) == 1 {
+		return nil, false
+	}
+	return hlog.serialize(), true
+}
+
+// Imports a serialized sketch into the log, creating the log with the precision
+// and algorithm of the sketch if it doesn't exist. If merge is true the sketch
+// is merged into the log, otherwise it replaces the slots of the log
+func (hc *HllContainer) ImportLog(key string, data []byte, expiry uint64, merge bool) error {
+	ok, sketch := deserialize(key, 0, data)
+	if !ok {
+		return ErrInvalidSketch
+	}
+	return hc.importSketch(key, sketch, expiry, merge)
+}
+
+// Imports a Redis hyperloglog string into the log, the log uses the REDIS
+// algorithm so that it can be updated with the items counted in Redis
+func (hc *HllContainer) ImportRedisLog(key string, data []byte, expiry uint64, merge bool) error {
+	ok, sketch := deserializeRedis(key, data)
+	if !ok {
+		return ErrInvalidSketch
+	}
+	return hc.importSketch(key, sketch, expiry, merge)
+}
+
+// Returns the log as a Redis hyperloglog string, only logs using the REDIS
+// algorithm can be exported
+func (hc *HllContainer) ExportRedisLog(key string) ([]byte, error) {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog := hc.hllmaps[slot].getLog(key)
+	if hlog == nil || atomic.LoadUint32(&hlog.deleted) == 1 {
+		return nil, ErrLogNotExists
+	}
+	if hlog.algo != REDIS {
+		return nil, ErrNotRedisLog
+	}
+	return hlog.redisSerialize(), nil
+}
+
+func (hc *HllContainer) importSketch(key string, sketch *hyperlog, expiry uint64,
+	merge bool) error {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	op := wALREPLACE
+	if merge {
+		op = wALSLOTS
+	}
+	_, err := hc.hllmaps[slot].updateLog(key, expiry, sketch.precision, sketch.algo, nil, op,
+		func(hlog *hyperlog) ([]uint32, bool, bool, error) {
+			// sketches don't carry the salt, the sketch must have been hashed
+			// with the salt of the log
+			sketch.salt = hlog.salt
+			if !hlog.compatible(sketch) {
+				return nil, false, false, ErrIncompatibleLogs
+			}
+			if merge {
+				return noErr(hlog.mergeentries(sketch.slotentries()))
+			}
+			if !hlog.replaceslots(sketch) {
+				return nil, false, false, nil
+			}
+			enqueue := atomic.AddInt32(&hlog.updated, 1) == 1
+			return hlog.slotentries(), enqueue, true, nil
+		})
+	return err
 }
 
 func (hc *HllContainer) GetCardinality(key string) uint64 {
@@ -301,71 +909,105 @@ func (hc *HllContainer) GetCardinality(key string) uint64 {
 	}
 }
 
-func (hc *HllContainer) Shutdown() {
-	hc.shutdown <- true
+// Returns the cardinality estimate with its error, a missing log is estimated
+// as an empty log with the default precision. A nil estimator uses the default
+func (hc *HllContainer) GetEstimate(key string, est Estimator) Estimate {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog := hc.hllmaps[slot].getLog(key)
+	if hlog != nil {
+		return hlog.estimate(est)
+	}
+	return emptyEstimate(est)
 }
 
-func (hc *HllContainer) doCleanup() {
-	hc.exmutex.RLock()
-	lenb := len(hc.expirym)
-	hc.exmutex.RUnlock()
-	if lenb == 0 {
-		return
-	}
-	var bkts hutil.Uint64Slice
-	tim := time.Now().Unix()
-	hc.exmutex.RLock()
-	for bkt, _ := range hc.expirym {
-		if bkt < uint64(tim) {
-			bkts = append(bkts, bkt)
-		}
-		// enough for cleaning up 40 buckets at a time
-		if bkts.Len() >= 40 {
-			break
-		}
-	}
-	hc.exmutex.RUnlock()
-	if bkts.Len() == 0 {
-		return
+// Like GetEstimate, but returns ErrLogNotExists for a missing log
+func (hc *HllContainer) GetLogEstimate(key string, est Estimator) (Estimate, error) {
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
+	hlog := hc.hllmaps[slot].getLog(key)
+	if hlog == nil {
+		return Estimate{}, ErrLogNotExists
 	}
-	sort.Sort(bkts)
-	for _, bkt := range bkts {
-		hc.exmutex.Lock()
-		exps, ok := hc.expirym[bkt]
-		if !ok {
-			hc.exmutex.Unlock()
-			continue
+	return hlog.estimate(est), nil
+}
+
+func emptyEstimate(est Estimator) Estimate {
+	return newHyperLog("", 0, 0, CLASSIC).estimate(est)
+}
+
+// Shuts down the container waiting at most sHUTDOWNTIMEOUT for the pending
+// updates to be written to the store
+func (hc *HllContainer) Shutdown() {
+	hc.ShutdownTimeout(sHUTDOWNTIMEOUT)
+}
+
+// Stops the background goroutines, then writes the pending updates to the
+// store and flushes it. Updates still pending when the timeout expires are
+// dropped, returns their number. When the background goroutines don't stop
+// before the timeout, e.g. blocked by a hanging store, they are abandoned and
+// all the pending updates are dropped. The logs must not be updated once the
+// shutdown starts
+func (hc *HllContainer) ShutdownTimeout(timeout time.Duration) int {
+	dropped := 0
+	hc.shutonce.Do(func() {
+		deadline := time.Now().Add(timeout)
+		close(hc.shutdown)
+		hc.ticker.Stop()
+		stopped := make(chan struct{})
+		go func() {
+			hc.workers.Wait()
+			close(stopped)
+		}()
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		select {
+		case <-stopped:
+		case <-timer.C:
+			// the store is still used by the abandoned goroutines
+			dropped = int(hc.pending.Load())
+			hllogs.Log.Errorf("Background workers didn't stop in %s, dropped %d pending updates on shutdown",
+				timeout, dropped)
+			return
 		}
-		delete(hc.expirym, bkt)
-		hc.exmutex.Unlock()
-		for key, expel := range exps {
-			expel.log.lock.Lock()
-			if expel.log.deleted > 0 {
-				expel.log.lock.Unlock()
-				continue
-			}
-			expel.log.deleted = 1
-			expel.log.lock.Unlock()
-			part := expel.part
-			hc.hllmaps[part].mutex.Lock()
-			delete(hc.hllmaps[part].logm, key)
-			hc.hllmaps[part].mutex.Unlock()
-			if hc.store != nil {
-				hc.store.Delete(key)
-			}
+		if hc.store != nil {
+			dropped = hc.drainUpdates(deadline)
 		}
-	}
+	})
+	return dropped
 }
 
-func (hc *HllContainer) cleanup() {
-	for {
+// Writes the queued logs to the store until the queues are empty or the
+// deadline passes, returns the number of logs left in the queues
+func (hc *HllContainer) drainUpdates(deadline time.Time) int {
+	drained := 0
+	for time.Now().Before(deadline) {
+		var hlog *hyperlog
 		select {
-		case _ = <-hc.ticker.C:
-			hc.doCleanup()
-		case _ = <-hc.shutdown:
+		case hlog = <-hc.updchan:
+		default:
+			hlog = hc.dequeueStoreUpd()
+		}
+		if hlog == nil {
 			break
 		}
+		hc.pending.Add(-1)
+		if err := hc.updateStore(hlog); err != nil {
+			// wait for the store to catch up
+			if hc.flushStore() != nil {
+				time.Sleep(100 * time.Millisecond)
+			}
+			continue
+		}
+		drained++
+	}
+	if err := hc.flushStore(); err != nil {
+		hllogs.Log.Errorf("Failed to flush store on shutdown: %s", err)
 	}
+	dropped := int(hc.pending.Load())
+	hllogs.Log.Infof("Wrote %d pending updates to store on shutdown", drained)
+	if dropped > 0 {
+		hllogs.Log.Errorf("Dropped %d pending updates on shutdown", dropped)
+	}
+	return dropped
 }
 
 func (hc *HllContainer) addToDb(upds *updLogs, maxupd uint32) {
@@ -381,63 +1023,191 @@ func (hc *HllContainer) addToDb(upds *updLogs, maxupd uint32) {
 		front := upds.lst.Front()
 		hlog := upds.lst.Remove(front).(*hyperlog)
 		upds.lock.Unlock()
-		hc.updchan <- hlog
+		select {
+		case hc.updchan <- hlog:
+		case _ = <-hc.shutdown:
+			// left for the shutdown to drain
+			upds.lock.Lock()
+			upds.lst.PushFront(hlog)
+			upds.lock.Unlock()
+			return
+		}
 		i++
 	}
 }
 
 func (hc *HllContainer) savechanges(upds *updLogs) {
+	defer hc.workers.Done()
 	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
 	for {
 		select {
 		case _ = <-ticker.C:
 			hc.addToDb(upds, 256)
 		case _ = <-hc.shutdown:
-			break
+			return
 		}
 	}
 }
 
 func (hc *HllContainer) enqueueStoreUpd(slot uint32, hlog *hyperlog) {
 	lstupd := hc.updates[slot&7]
+	hc.pending.Add(1)
 	lstupd.lock.Lock()
 	defer lstupd.lock.Unlock()
 	lstupd.lst.PushBack(hlog)
 }
 
-func (hc *HllContainer) updateStore(hlog *hyperlog) {
+// Limits the logs queued for store update, when the limit is reached StoreErr
+// returns ErrStoreOverloaded. 0 means no limit
+func (hc *HllContainer) SetMaxPendingUpdates(max int) {
+	hc.maxpending.Store(int64(max))
+}
+
+// Returns an error if updates can't be persisted, because too many of them are
+// pending for the store or because the store or the write ahead log is
+// failing. Updates should be rejected then instead of piling up in memory
+func (hc *HllContainer) StoreErr() error {
+	if hc.store == nil {
+		return nil
+	}
+	if max := hc.maxpending.Load(); max > 0 && hc.pending.Load() >= max {
+		return ErrStoreOverloaded
+	}
+	if hc.wal != nil {
+		if err := hc.wal.Err(); err != nil {
+			return err
+		}
+	}
+	return hc.store.Err()
+}
+
+// Removes the deleted logs from the store update queues and returns them
+func (hc *HllContainer) takeQueuedDeletes() []*hyperlog {
+	var ret []*hyperlog
+	for _, upds := range hc.updates {
+		upds.lock.Lock()
+		for e := upds.lst.Front(); e != nil; {
+			next := e.Next()
+			if hlog := e.Value.(*hyperlog); atomic.LoadUint32(&hlog.deleted) == 1 {
+				upds.lst.Remove(e)
+				hc.pending.Add(-1)
+				ret = append(ret, hlog)
+			}
+			e = next
+		}
+		upds.lock.Unlock()
+	}
+	return ret
+}
+
+// Removes and returns a log from the update lists, nil if they are empty
+func (hc *HllContainer) dequeueStoreUpd() *hyperlog {
+	for _, upds := range hc.updates {
+		upds.lock.Lock()
+		front := upds.lst.Front()
+		if front != nil {
+			hlog := upds.lst.Remove(front).(*hyperlog)
+			upds.lock.Unlock()
+			return hlog
+		}
+		upds.lock.Unlock()
+	}
+	return nil
+}
+
+// Writes the log to the store. The log is enqueued again if it changed
+// meanwhile or if the store didn't take the update. The delete of a log is
+// replaced by the update of the newer log of the key if there is one, so that
+// a delete enqueued again can't remove the newer log from the store
+func (hc *HllContainer) updateStore(hlog *hyperlog) error {
 	key := hlog.key
 	deleted := atomic.LoadUint32(&hlog.deleted)
+	slot := murmur3_32([]byte(key), sEED) & hc.hslot
 	var updcount int32
+	var err error
+	if deleted > 0 {
+		if newer := hc.hllmaps[slot].newerLog(hlog); newer != nil {
+			// the record of the newer log overwrites the stored one
+			if atomic.AddInt32(&newer.updated, 1) == 1 {
+				hc.enqueueStoreUpd(slot, newer)
+			}
+		} else {
+			err = hc.store.Delete(key)
+		}
+	} else {
+		updcount = hlog.getUpdCount()
+		data := hlog.serializeRecord()
+		expiry := hlog.expiry
+		err = hc.store.Update(key, expiry, data)
+	}
+	if err == hllstore.ErrInvalidKey || err == hllstore.ErrInvalidValue {
+		// retrying can't help
+		hllogs.Log.Errorf("Dropped store update of key %s: %s", key, err)
+		err = nil
+	} else if err != nil {
+		updcount = 0
+	} else if deleted == 0 {
+		// persisted once the store is flushed
+		atomic.StoreUint32(&hlog.stored, 0)
+		hc.unflushed = append(hc.unflushed, hlog)
+	}
 	if deleted > 0 {
-		hc.store.Delete(key)
+		if err != nil {
+			hc.enqueueStoreUpd(slot, hlog)
+			return err
+		}
 		if hlog.delwaiter > 0 {
-			hc.store.Flush()
+			if ferr := hc.store.Flush(); ferr != nil {
+				hllogs.Log.Errorf("Delete of key %s not yet committed: %s", key, ferr)
+			}
 			i := hlog.delwaiter
 			for i != 0 {
 				hlog.delwait.Done()
 				i--
 			}
+			hlog.delwaiter = 0
 		}
-	} else {
-		updcount = hlog.getUpdCount()
-		data := hlog.serialize()
-		expiry := hlog.expiry
-		hc.store.Update(key, expiry, data)
+		return nil
 	}
-	if hc.store != nil && deleted != 1 && hlog.processed(-updcount) > 0 {
-		slot := murmur3_32([]byte(hlog.key), sEED) & hc.hslot
+	if hlog.processed(-updcount) > 0 {
 		hc.enqueueStoreUpd(slot, hlog)
 	}
+	return err
+}
+
+// Flushes the store and marks the logs sent to it since the last flush as
+// persisted. Must be called from the store updates goroutine
+func (hc *HllContainer) flushStore() error {
+	if err := hc.store.Flush(); err != nil {
+		return err
+	}
+	for _, hlog := range hc.unflushed {
+		atomic.StoreUint32(&hlog.stored, 1)
+	}
+	hc.unflushed = nil
+	return nil
 }
 
 func (hc *HllContainer) storeUpdates() {
+	defer hc.workers.Done()
+	ticker := time.NewTicker(sTOREFLUSHINTERVAL)
+	defer ticker.Stop()
 	for {
 		select {
 		case hlog := <-hc.updchan:
+			hc.pending.Add(-1)
 			hc.updateStore(hlog)
+		case <-ticker.C:
+			// a failed flush is logged by the store and retried on the
+			// next tick
+			if len(hc.unflushed) > 0 {
+				hc.flushStore()
+			}
+		case done := <-hc.ckptchan:
+			done <- hc.flushLogs()
 		case _ = <-hc.shutdown:
-			break
+			return
 		}
 	}
 }
@@ -445,35 +1215,37 @@ func (hc *HllContainer) storeUpdates() {
 func (hc *HllContainer) Process(key string, expiry uint64, data []byte) error {
 	// Must be called during startup only
 	hllogs.Log.Debugf("Trying to restore %s", key)
-	now := uint64(time.Now().Unix())
+	now := nowMillis()
 	if expiry > 0 && expiry <= now {
 		hllogs.Log.Infof("Key %s, has expiry %d, less than current time %d, deleting...",
 			key, expiry, now)
 		hc.delete_first = append(hc.delete_first, key)
 		return nil
 	}
-	ok, hlog := deserialize(key, expiry, data)
+	ok, hlog := deserializeRecord(key, expiry, data)
 	if !ok {
 		return errors.New("Error in decoding hyperlog")
 	}
 
 	slot := murmur3_32([]byte(key), sEED) & hc.hslot
 	hm := hc.hllmaps[slot]
+	hlog.stored = 1
 	hm.logm[key] = hlog
-	if hlog.expiry > 0 {
-		expbkt := hlog.expiry&eXPBK + 64
-		em, ok := hm.hlc.expirym[expbkt]
-		if !ok {
-			em = make(map[string]*expm)
-			hm.hlc.expirym[expbkt] = em
-		}
-		em[key] = newExpm(slot, hlog)
-	}
+	hm.setLogExpiry(hlog, hlog.expiry)
 
 	hllogs.Log.Debugf("Restored log for key:%s", key)
 	return nil
 }
 
+// Returns the keys quarantined during restore because their stored records
+// were corrupt or undecodable
+func (hc *HllContainer) Quarantined() ([]hllstore.QuarantinedKey, error) {
+	if hc.store == nil {
+		return nil, nil
+	}
+	return hc.store.Quarantined()
+}
+
 func (hc *HllContainer) restore() {
 	// Must be called during startup only
 	if hc.store == nil {
@@ -1,15 +1,25 @@
 package hll
 
 import (
+	"bytes"
+	"encoding/binary"
 	"github.com/nipuntalukdar/bitset"
 	"math"
+	"math/bits"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type hyperlog struct {
 	key            string
 	slot           []uint32
+	sparse         []uint32
+	dense          uint32
+	precision      uint8
+	algo           uint8
+	hashbits       uint8
 	numslot        uint32
 	numnonzeroslot uint32
 	lock           *sync.RWMutex
@@ -18,43 +28,369 @@ type hyperlog struct {
 	expiry         uint64
 	delwait        sync.WaitGroup
 	delwaiter      uint32
+	// index in the expiry heap of its map, -1 if not in it
+	expidx int
+	// creation and last modification unix times, 0 if unknown
+	ctime uint64
+	mtime uint64
+	// 1 once the latest record of the log sent to the store is committed
+	stored uint32
+	// held while changing the slots and appending their write ahead log
+	// record and while deleting the log, so that the records of the log are
+	// in the order of its changes
+	wallock sync.Mutex
+	// secret sALTLEN bytes SipHash key, nil if items are hashed with murmur3
+	salt []byte
 }
 
 const (
-	sLOT     uint32  = 256
-	bITSS    uint32  = (256 * 5) / 8
-	sLOTF    float64 = float64(sLOT)
-	tWOPO32  uint64  = 0x00000100000000
-	tWOPO32F float64 = float64(tWOPO32)
-	fVAL     float64 = (0.7213 / (1 + 1.079/sLOTF)) * sLOTF * sLOTF
-	cMP1     float64 = 2.5 * sLOTF
-	cMP2     float64 = tWOPO32F / 30.0
+	CLASSIC uint8 = iota
+	HLLPP
+	// hyperlog++ registers with the hashing of Redis, so that logs imported
+	// from Redis can keep on counting the same items
+	REDIS
 )
 
-func newHyperLog(logkey string, expiry uint64) *hyperlog {
-	slot := make([]uint32, sLOT)
-	return &hyperlog{key: logkey, slot: slot, numslot: sLOT, numnonzeroslot: 0,
-		lock: &sync.RWMutex{}, updated: 0, expiry: expiry}
+const (
+	dEFPRECISION uint8   = 8
+	mINPRECISION uint8   = 4
+	mAXPRECISION uint8   = 18
+	sLOT         uint32  = 1 << dEFPRECISION
+	bITSS        uint32  = (256 * 5) / 8
+	tWOPO32      uint64  = 0x00000100000000
+	tWOPO32F     float64 = float64(tWOPO32)
+	cMP2         float64 = tWOPO32F / 30.0
+	pRECMARKER   byte    = 0xfe
+	eNCSPARSE    byte    = 0
+	eNCDENSE     byte    = 1
+	sPARSEDIV    uint32  = 4
+	rEDISSEED    uint64  = 0xadc83b19
+	rEDISPREC    uint8   = 14
+	sALTLEN      int     = 16
+)
+
+// Returned by updateslot instead of the update count when the log was removed
+// meanwhile, e.g. by a rename
+const dEADLOG int32 = -1
+
+func validPrecision(precision uint8) bool {
+	return precision >= mINPRECISION && precision <= mAXPRECISION
+}
+
+func validAlgo(algo uint8) bool {
+	return algo == CLASSIC || algo == HLLPP || algo == REDIS
+}
+
+// Precision 0 selects the default precision of the algorithm
+func validLogParams(precision uint8, algo uint8) bool {
+	if !validAlgo(algo) || (precision != 0 && !validPrecision(precision)) {
+		return false
+	}
+	return algo != REDIS || precision == 0 || precision == rEDISPREC
+}
+
+func alpha(m uint32) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+func newHyperLog(logkey string, expiry uint64, precision uint8, algo uint8) *hyperlog {
+	if algo == REDIS {
+		precision = rEDISPREC
+	} else if precision == 0 {
+		precision = dEFPRECISION
+	}
+	// classic hyperlog works on 32 bit hashes, hyperlog++ on 64 bit hashes
+	hashbits := uint8(32)
+	if algo != CLASSIC {
+		hashbits = 64
+	}
+	// A new log starts in the sparse mode and is promoted to dense mode once
+	// the number of non-zero slots crosses numslot / sPARSEDIV
+	now := uint64(time.Now().Unix())
+	return &hyperlog{key: logkey, precision: precision, algo: algo, hashbits: hashbits,
+		numslot: uint32(1) << precision, numnonzeroslot: 0, lock: &sync.RWMutex{},
+		updated: 0, expiry: expiry, expidx: -1, ctime: now, mtime: now}
+}
+
+func (hpl *hyperlog) isdense() bool {
+	return atomic.LoadUint32(&hpl.dense) == 1
+}
+
+// Must be called with the write lock held
+func (hpl *hyperlog) promote() {
+	slot := make([]uint32, hpl.numslot)
+	for _, entry := range hpl.sparse {
+		slot[entry>>8] = entry & 0xff
+	}
+	hpl.slot = slot
+	hpl.sparse = nil
+	atomic.StoreUint32(&hpl.dense, 1)
+}
+
+// Must be called with the lock held
+func (hpl *hyperlog) getslot(idx uint32) uint32 {
+	if hpl.slot != nil {
+		return atomic.LoadUint32(&hpl.slot[idx])
+	}
+	i := sort.Search(len(hpl.sparse), func(i int) bool { return hpl.sparse[i]>>8 >= idx })
+	if i < len(hpl.sparse) && hpl.sparse[i]>>8 == idx {
+		return hpl.sparse[i] & 0xff
+	}
+	return 0
+}
+
+// Must be called with the lock held, calls fn for each non-zero slot in the
+// increasing order of slot index
+func (hpl *hyperlog) foreachslot(fn func(idx uint32, val uint32)) {
+	if hpl.slot == nil {
+		for _, entry := range hpl.sparse {
+			fn(entry>>8, entry&0xff)
+		}
+		return
+	}
+	for i := range hpl.slot {
+		val := atomic.LoadUint32(&hpl.slot[i])
+		if val != 0 {
+			fn(uint32(i), val)
+		}
+	}
+}
+
+func (hpl *hyperlog) hash(entry []byte) uint64 {
+	if hpl.salt != nil {
+		h := siphash(hpl.salt, entry)
+		if hpl.algo == CLASSIC {
+			return h & 0xffffffff
+		}
+		return h
+	}
+	switch hpl.algo {
+	case HLLPP:
+		return murmur3_64(entry, sEED)
+	case REDIS:
+		return murmur3_64(entry, rEDISSEED)
+	}
+	return uint64(murmur3_32(entry, sEED))
+}
+
+// Number of bits needed to store the value of a slot
+func (hpl *hyperlog) slotbits() uint32 {
+	if hpl.algo == CLASSIC {
+		return 5
+	}
+	return 6
+}
+
+func (hpl *hyperlog) addhash(val uint64) (int32, bool) {
+	return hpl.updateslot(hpl.slotval(val))
+}
+
+// Returns the index of the slot the hash selects and the value for the slot
+func (hpl *hyperlog) slotval(val uint64) (uint32, uint32) {
+	if hpl.algo == REDIS {
+		// like redis, low precision bits select the slot and the value is the
+		// trailing zeros count + 1 in the remaining bits
+		idx := val & uint64(hpl.numslot-1)
+		val = val>>hpl.precision | uint64(1)<<(64-hpl.precision)
+		return uint32(idx), uint32(bits.TrailingZeros64(val)) + 1
+	}
+	// top precision bits of the hash select the slot, value at the slot
+	// is set to leading zeros count + 1 in the remaining bits
+	rembits := uint32(hpl.hashbits - hpl.precision)
+	idx := val >> rembits
+	leadzs := uint32(bits.LeadingZeros64(val << (64 - rembits)))
+	if leadzs > rembits {
+		leadzs = rembits
+	}
+	return uint32(idx), leadzs + 1
 }
 
-func (hpl *hyperlog) addhash(val uint32) (int32, bool) {
-	idx := byte(val >> 24)
-	var andwth uint32 = 0x00800000
-	var leadzs uint32 = 0
-	i := 23
-	for {
-		if (val & andwth) != 0 {
+// Adds the hashes, returns the slots changed as index<<8 | value entries and
+// true if the log needs to be enqueued for store update. Returns false last if
+// the log was removed meanwhile, the remaining hashes aren't added then
+func (hpl *hyperlog) addhashes(hashes []uint64) ([]uint32, bool, bool) {
+	var changes []uint32
+	enqueue := false
+	live := true
+	for _, h := range hashes {
+		idx, val := hpl.slotval(h)
+		newval, updated := hpl.updateslot(idx, val)
+		if newval == dEADLOG {
+			live = false
 			break
 		}
-		leadzs += 1
-		if i == 0 {
+		if updated {
+			changes = append(changes, idx<<8|val)
+			if newval == 1 {
+				enqueue = true
+			}
+		}
+	}
+	if changes != nil {
+		hpl.touch()
+	}
+	return changes, enqueue, live
+}
+
+// Sets the slot at idx to val if the current value is less than val. Returns
+// dEADLOG if the log was removed
+func (hpl *hyperlog) updateslot(idx uint32, val uint32) (int32, bool) {
+	if !hpl.isdense() {
+		return hpl.addsparse(idx, val)
+	}
+	return hpl.adddense(idx, val)
+}
+
+// Returns the non-zero slots as index<<8 | value entries
+func (hpl *hyperlog) slotentries() []uint32 {
+	hpl.lock.RLock()
+	defer hpl.lock.RUnlock()
+	entries := make([]uint32, 0, atomic.LoadUint32(&hpl.numnonzeroslot))
+	hpl.foreachslot(func(idx uint32, val uint32) {
+		entries = append(entries, idx<<8|val)
+	})
+	return entries
+}
+
+func (hpl *hyperlog) compatible(other *hyperlog) bool {
+	return hpl.algo == other.algo && hpl.precision == other.precision &&
+		bytes.Equal(hpl.salt, other.salt)
+}
+
+// Sets each slot to the max of its value and the value in other. Returns
+// true if the log needs to be enqueued for store update
+func (hpl *hyperlog) mergefrom(other *hyperlog) bool {
+	_, enqueue, _ := hpl.mergeentries(other.slotentries())
+	return enqueue
+}
+
+// Sets the slot of each index<<8 | value entry to the max of its value and the
+// entry value. Returns the entries which changed a slot, reusing the entries
+// slice, and true if the log needs to be enqueued for store update. Returns
+// false last if the log was removed meanwhile, like addhashes
+func (hpl *hyperlog) mergeentries(entries []uint32) ([]uint32, bool, bool) {
+	enqueue := false
+	live := true
+	changes := entries[:0]
+	for _, entry := range entries {
+		newval, updated := hpl.updateslot(entry>>8, entry&0xff)
+		if newval == dEADLOG {
+			live = false
 			break
 		}
-		i--
-		andwth = andwth >> 1
+		if updated {
+			changes = append(changes, entry)
+			if newval == 1 {
+				enqueue = true
+			}
+		}
+	}
+	if len(changes) > 0 {
+		hpl.touch()
+	}
+	return changes, enqueue, live
+}
+
+// Replaces the slots with the slots of other, other must be compatible and
+// not shared. Returns false if the log was removed, it isn't changed then
+func (hpl *hyperlog) replaceslots(other *hyperlog) bool {
+	hpl.lock.Lock()
+	defer hpl.lock.Unlock()
+	if atomic.LoadUint32(&hpl.deleted) == 1 {
+		return false
+	}
+	if hpl.dense == 1 {
+		// a dense log never goes back to sparse, dense slots are read
+		// without the lock
+		if other.dense == 0 {
+			other.promote()
+		}
+		for i, val := range other.slot {
+			atomic.StoreUint32(&hpl.slot[i], val)
+		}
+	} else if other.dense == 1 {
+		hpl.slot = other.slot
+		hpl.sparse = nil
+		atomic.StoreUint32(&hpl.dense, 1)
+	} else {
+		hpl.sparse = other.sparse
+	}
+	atomic.StoreUint32(&hpl.numnonzeroslot, other.numnonzeroslot)
+	hpl.touch()
+	return true
+}
+
+// Returns a copy of the log with the key, created now and without expiry. With
+// remove the log is marked deleted together, so that the updates which missed
+// the copy are rejected instead of being lost
+func (hpl *hyperlog) clone(key string, remove bool) *hyperlog {
+	hpl.lock.Lock()
+	defer hpl.lock.Unlock()
+	if remove {
+		atomic.StoreUint32(&hpl.deleted, 1)
+	}
+	other := newHyperLog(key, 0, hpl.precision, hpl.algo)
+	other.salt = hpl.salt
+	if hpl.dense == 1 {
+		other.slot = append([]uint32(nil), hpl.slot...)
+		other.dense = 1
+	} else {
+		other.sparse = append([]uint32(nil), hpl.sparse...)
+	}
+	other.numnonzeroslot = hpl.numnonzeroslot
+	return other
+}
+
+// Sets the modification time of the log to now
+func (hpl *hyperlog) touch() {
+	atomic.StoreUint64(&hpl.mtime, uint64(time.Now().Unix()))
+}
+
+// Relative standard error of the cardinality estimate
+func (hpl *hyperlog) stderror() float64 {
+	return 1.04 / math.Sqrt(float64(hpl.numslot))
+}
+
+func (hpl *hyperlog) addsparse(idx uint32, leadzs uint32) (int32, bool) {
+	hpl.lock.Lock()
+	if atomic.LoadUint32(&hpl.deleted) == 1 {
+		hpl.lock.Unlock()
+		return dEADLOG, false
+	}
+	if hpl.dense == 1 {
+		// got promoted in between
+		hpl.lock.Unlock()
+		return hpl.adddense(idx, leadzs)
+	}
+	i := sort.Search(len(hpl.sparse), func(i int) bool { return hpl.sparse[i]>>8 >= idx })
+	if i < len(hpl.sparse) && hpl.sparse[i]>>8 == idx {
+		if hpl.sparse[i]&0xff >= leadzs {
+			hpl.lock.Unlock()
+			return 0, false
+		}
+		hpl.sparse[i] = idx<<8 | leadzs
+	} else {
+		hpl.sparse = append(hpl.sparse, 0)
+		copy(hpl.sparse[i+1:], hpl.sparse[i:])
+		hpl.sparse[i] = idx<<8 | leadzs
+		hpl.numnonzeroslot++
+		if hpl.numnonzeroslot > hpl.numslot/sPARSEDIV {
+			hpl.promote()
+		}
 	}
-	// value at index set to leading zeros count + 1
-	leadzs += 1
+	hpl.lock.Unlock()
+	return atomic.AddInt32(&hpl.updated, 1), true
+}
+
+func (hpl *hyperlog) adddense(idx uint32, leadzs uint32) (int32, bool) {
 	curval := atomic.LoadUint32(&hpl.slot[idx])
 	if curval >= leadzs {
 		return 0, false
@@ -63,6 +399,9 @@ func (hpl *hyperlog) addhash(val uint32) (int32, bool) {
 	updated := false
 	hpl.lock.RLock()
 	defer hpl.lock.RUnlock()
+	if atomic.LoadUint32(&hpl.deleted) == 1 {
+		return dEADLOG, false
+	}
 	for curval < leadzs {
 		if atomic.CompareAndSwapUint32(&hpl.slot[idx], curval, leadzs) {
 			if curval == 0 {
@@ -89,53 +428,80 @@ func (hpl *hyperlog) getUpdCount() int32 {
 	return atomic.LoadInt32(&hpl.updated)
 }
 
+// Returns true if the log has an expiry at or before the unix time now in
+// milliseconds
+func (hpl *hyperlog) expired(now uint64) bool {
+	return hpl.expiry > 0 && hpl.expiry <= now
+}
+
 func (hpl *hyperlog) count_cardinality() uint64 {
-	var i uint32 = 0
-	sum := 0.0
+	return hpl.estimate(nil).Cardinality
+}
+
+// Estimates the cardinality with the estimator, nil for the default estimator
+func (hpl *hyperlog) estimate(est Estimator) Estimate {
+	if est == nil {
+		est = estimators[defEstimator.Load().(string)]
+	}
+	card, estimator := est.Estimate(hpl.histogram(), hpl.precision, hpl.algo)
+	return newEstimate(card, estimator, hpl.stderror())
+}
+
+// Returns the number of slots for each slot value, values above the number of
+// hash bits left after the slot index plus one are counted with the largest
+func (hpl *hyperlog) histogram() []uint32 {
 	hpl.lock.RLock()
 	defer hpl.lock.RUnlock()
-	for i < sLOT {
-		x := 1 << hpl.slot[i]
-		sum += 1.0 / float64(x)
-		i++
-	}
-	ret := fVAL / sum
-	if ret <= cMP1 {
-		i = 0
-		var v float64 = float64(sLOT - hpl.numnonzeroslot)
-		if v != 0 {
-			return uint64(sLOTF * math.Log(sLOTF/v))
-		} else {
-			return uint64(ret)
+	maxval := uint32(hpl.hashbits-hpl.precision) + 1
+	hist := make([]uint32, maxval+1)
+	nonzero := uint32(0)
+	hpl.foreachslot(func(idx uint32, val uint32) {
+		if val > maxval {
+			val = maxval
 		}
-	} else if ret <= cMP2 {
-		return uint64(ret)
+		hist[val]++
+		nonzero++
+	})
+	hist[0] = hpl.numslot - nonzero
+	return hist
+}
+
+func estimateBias(precision uint8, rawest float64) float64 {
+	estimates := rawEstimateData[precision-mINPRECISION]
+	biases := biasData[precision-mINPRECISION]
+	idx := sort.SearchFloat64s(estimates, rawest)
+	if idx == 0 {
+		return biases[0]
+	} else if idx == len(estimates) {
+		return biases[len(biases)-1]
 	}
-	ret = -(tWOPO32F * math.Log(1.0-ret/tWOPO32F))
-	return uint64(ret)
+	// Linear interpolation between the two closest estimates
+	e1, e2 := estimates[idx-1], estimates[idx]
+	b1, b2 := biases[idx-1], biases[idx]
+	r := (rawest - e1) / (e2 - e1)
+	return b1*(1-r) + b2*r
 }
 
 func (hpl *hyperlog) serialize() []byte {
 	hpl.lock.Lock()
 	defer hpl.lock.Unlock()
 
+	if hpl.precision != dEFPRECISION || hpl.algo != CLASSIC {
+		return hpl.serializePrecision()
+	}
 	// if number of set index <= 80, then return the array as
 	// array of set indices, followed by the array of values set at those
 	// indices,
 	// Otherwise return an arry from bitset
 	if hpl.numnonzeroslot <= 80 {
 		ret := make([]byte, hpl.numnonzeroslot<<1+1)
-		i := uint32(0)
 		curset := uint32(1)
 		ret[0] = byte(hpl.numnonzeroslot)
-		for i < sLOT {
-			if hpl.slot[i] != 0 {
-				ret[curset] = byte(i)
-				ret[curset+hpl.numnonzeroslot] = byte(hpl.slot[i])
-				curset++
-			}
-			i++
-		}
+		hpl.foreachslot(func(idx uint32, val uint32) {
+			ret[curset] = byte(idx)
+			ret[curset+hpl.numnonzeroslot] = byte(val)
+			curset++
+		})
 		return ret
 	}
 	bs := bitset.NewBitset(bITSS + 1)
@@ -148,9 +514,116 @@ func (hpl *hyperlog) serialize() []byte {
 	return bs.GetBytesUnsafe()
 }
 
+// Logs with non-default precision or algorithm are serialized as:
+// marker(0xfe), precision, algorithm<<4 | encoding followed by either the
+// delta encoded (index, value) pairs of the non-zero slots if the log is in
+// sparse mode or the slots packed in 5 (classic) or 6 (hyperlog++) bits each
+func (hpl *hyperlog) serializePrecision() []byte {
+	if hpl.slot == nil {
+		ret := make([]byte, 3, 3+binary.MaxVarintLen32+hpl.numnonzeroslot*4)
+		ret[0] = pRECMARKER
+		ret[1] = hpl.precision
+		ret[2] = hpl.algo<<4 | eNCSPARSE
+		ret = binary.AppendUvarint(ret, uint64(hpl.numnonzeroslot))
+		previdx := uint32(0)
+		for _, entry := range hpl.sparse {
+			ret = binary.AppendUvarint(ret, uint64(entry>>8-previdx))
+			ret = append(ret, byte(entry&0xff))
+			previdx = entry >> 8
+		}
+		return ret
+	}
+	slotbits := hpl.slotbits()
+	bs := bitset.NewBitset((hpl.numslot*slotbits+7)/8 + 3)
+	bs.SetVal(0, 7, uint32(pRECMARKER))
+	bs.SetVal(8, 15, uint32(hpl.precision))
+	bs.SetVal(16, 23, uint32(hpl.algo<<4|eNCDENSE))
+	pos := uint32(24)
+	for _, v := range hpl.slot {
+		bs.SetVal(pos, pos+slotbits-1, v)
+		pos += slotbits
+	}
+	return bs.GetBytesUnsafe()
+}
+
+// Must be called only on a log which is not yet shared, entries are
+// index<<8 | value for the non-zero slots
+func (hpl *hyperlog) loadsparse(entries []uint32) bool {
+	sort.Slice(entries, func(i, j int) bool { return entries[i] < entries[j] })
+	for i, entry := range entries {
+		if entry>>8 >= hpl.numslot || entry&0xff == 0 ||
+			(i > 0 && entries[i-1]>>8 == entry>>8) {
+			return false
+		}
+	}
+	hpl.sparse = entries
+	hpl.numnonzeroslot = uint32(len(entries))
+	if hpl.numnonzeroslot > hpl.numslot/sPARSEDIV {
+		hpl.promote()
+	}
+	return true
+}
+
+func deserializePrecision(key string, expiry uint64, data []byte) (bool, *hyperlog) {
+	if len(data) < 3 || !validPrecision(data[1]) || !validLogParams(data[1], data[2]>>4) {
+		return false, nil
+	}
+	hpl := newHyperLog(key, expiry, data[1], data[2]>>4)
+	slotbits := hpl.slotbits()
+	switch data[2] & 0x0f {
+	case eNCSPARSE:
+		count, n := binary.Uvarint(data[3:])
+		if n <= 0 || count > uint64(hpl.numslot) {
+			return false, nil
+		}
+		pos := 3 + n
+		idx := uint64(0)
+		entries := make([]uint32, 0, count)
+		for i := uint64(0); i < count; i++ {
+			delta, n := binary.Uvarint(data[pos:])
+			if n <= 0 || pos+n >= len(data) || (i > 0 && delta == 0) {
+				return false, nil
+			}
+			idx += delta
+			if idx >= uint64(hpl.numslot) {
+				return false, nil
+			}
+			entries = append(entries, uint32(idx)<<8|uint32(data[pos+n]))
+			pos += n + 1
+		}
+		if pos != len(data) || !hpl.loadsparse(entries) {
+			return false, nil
+		}
+	case eNCDENSE:
+		if uint32(len(data)) != (hpl.numslot*slotbits+7)/8+3 {
+			return false, nil
+		}
+		hpl.promote()
+		bs := bitset.NewBitsetFromArray(data[3:])
+		start := uint32(0)
+		for i := uint32(0); i < hpl.numslot; i++ {
+			val, err := bs.GetVal(start, start+slotbits-1)
+			if err != nil {
+				return false, nil
+			}
+			hpl.slot[i] = val
+			if val > 0 {
+				hpl.numnonzeroslot += 1
+			}
+			start += slotbits
+		}
+	default:
+		return false, nil
+	}
+	return true, hpl
+}
+
 func deserialize(key string, expiry uint64, data []byte) (bool, *hyperlog) {
 	datalen := uint32(len(data))
-	if datalen <= 1 || datalen&1 == 0 {
+	if datalen > 0 && data[0] == pRECMARKER {
+		return deserializePrecision(key, expiry, data)
+	}
+	if datalen == 0 || datalen&1 == 0 {
 		return false, nil
 	}
 	if data[0] == 0xff {
@@ -162,17 +635,21 @@ func deserialize(key string, expiry uint64, data []byte) (bool, *hyperlog) {
 			return false, nil
 		}
 	}
-	hpl := newHyperLog(key, expiry)
+	hpl := newHyperLog(key, expiry, dEFPRECISION, CLASSIC)
 	if data[0] != 0xff {
 		actual_size := uint32(data[0])
+		entries := make([]uint32, actual_size)
 		start := uint32(1)
 		for start <= actual_size {
-			hpl.slot[data[start]] = uint32(data[start+actual_size])
+			entries[start-1] = uint32(data[start])<<8 | uint32(data[start+actual_size])
 			start++
 		}
-		hpl.numnonzeroslot = actual_size
+		if !hpl.loadsparse(entries) {
+			return false, nil
+		}
 	} else {
 		// Data was in a bitset
+		hpl.promote()
 		bs := bitset.NewBitsetFromArray(data[1:])
 		start := uint32(0)
 		cur_indx := uint32(0)
@@ -1,20 +1,50 @@
 package hll
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/nipuntalukdar/hllserver/hllogs"
+	"github.com/nipuntalukdar/hllserver/hllstore"
+	"math"
+	"math/bits"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
+func allslots(hpl *hyperlog) []uint32 {
+	ret := make([]uint32, hpl.numslot)
+	hpl.foreachslot(func(idx uint32, val uint32) {
+		ret[idx] = val
+	})
+	return ret
+}
+
+// Returns whether the change of an existing log was made
+func succeeded(ok bool, err error) bool {
+	return ok && err == nil
+}
+
+func TestMain(m *testing.M) {
+	hllogs.InitLogger(10, 1024, filepath.Join(os.TempDir(), "hll_test.log"), "INFO")
+	os.Exit(m.Run())
+}
+
 func TestHyperLog(t *testing.T) {
-	hpl := newHyperLog("1", 0)
-	hpl2 := newHyperLog("2", 0)
+	hpl := newHyperLog("1", 0, 0, CLASSIC)
+	hpl2 := newHyperLog("2", 0, 0, CLASSIC)
 	var i uint32 = 0
 	mp := make(map[uint32]uint32)
 	rand.Seed(42)
 	for i < 100000 {
 		i++
 		v := rand.Uint32()
-		hpl.addhash(v)
+		hpl.addhash(uint64(v))
 		mp[v] = 1
 	}
 	t.Logf("Real cardinality %d", len(mp))
@@ -24,31 +54,31 @@ func TestHyperLog(t *testing.T) {
 	for i < 10 {
 		i++
 		v := rand.Uint32()
-		hpl2.addhash(v)
+		hpl2.addhash(uint64(v))
 	}
 	t.Logf("Computed Cardinality for small inputs %d\n", hpl2.count_cardinality())
 }
 
 func TestHyperLogSerialize(t *testing.T) {
-	hpl := newHyperLog("1", 0)
+	hpl := newHyperLog("1", 0, 0, CLASSIC)
 	i := 0
 	for i < 10 {
 		i++
 		v := rand.Uint32()
-		hpl.addhash(v)
+		hpl.addhash(uint64(v))
 	}
 	bytes := hpl.serialize()
 	if len(bytes) != 21 {
 		t.Fatalf("Serialization failed %d", len(bytes))
 	}
 	t.Logf("The returned array size %d", len(bytes))
-	hpl2 := newHyperLog("2", 0)
+	hpl2 := newHyperLog("2", 0, 0, CLASSIC)
 	i = 0
 	rand.Seed(42)
 	for i < 100000 {
 		i++
 		v := rand.Uint32()
-		hpl2.addhash(v)
+		hpl2.addhash(uint64(v))
 	}
 	bytes = hpl2.serialize()
 	if len(bytes) != 161 {
@@ -56,3 +86,1483 @@ func TestHyperLogSerialize(t *testing.T) {
 	}
 	t.Logf("The returned array size %d", len(bytes))
 }
+
+func TestHyperLogPrecision(t *testing.T) {
+	rand.Seed(42)
+	for _, precision := range []uint8{4, 12, 14, 18} {
+		hpl := newHyperLog("1", 0, precision, CLASSIC)
+		if hpl.numslot != 1<<precision {
+			t.Fatalf("Incorrect number of slots %d for precision %d", hpl.numslot, precision)
+		}
+		i := 0
+		for i < 10 {
+			i++
+			hpl.addhash(uint64(rand.Uint32()))
+		}
+		bytes := hpl.serialize()
+		ok, hpl2 := deserialize("1", 0, bytes)
+		if !ok || hpl2.precision != precision || !reflect.DeepEqual(allslots(hpl), allslots(hpl2)) {
+			t.Fatalf("Sparse serialization failed for precision %d", precision)
+		}
+		for i < 200000 {
+			i++
+			hpl.addhash(uint64(rand.Uint32()))
+		}
+		bytes = hpl.serialize()
+		ok, hpl2 = deserialize("1", 0, bytes)
+		if !ok || hpl2.numnonzeroslot != hpl.numnonzeroslot ||
+			!reflect.DeepEqual(allslots(hpl), allslots(hpl2)) {
+			t.Fatalf("Dense serialization failed for precision %d", precision)
+		}
+		t.Logf("Precision %d, serialized size %d, computed cardinality %d", precision,
+			len(bytes), hpl.count_cardinality())
+	}
+	if newHyperLog("1", 0, 0, CLASSIC).precision != dEFPRECISION {
+		t.Fatal("Default precision not applied")
+	}
+}
+
+func TestHyperLogPlusPlus(t *testing.T) {
+	rand.Seed(42)
+	for _, precision := range []uint8{8, 14} {
+		hpl := newHyperLog("1", 0, precision, HLLPP)
+		added := 0
+		for _, card := range []int{10, 1000, 100000, 1000000} {
+			for added < card {
+				added++
+				hpl.addhash(hpl.hash([]byte(fmt.Sprintf("item%d", added))))
+			}
+			est := hpl.count_cardinality()
+			relerr := math.Abs(float64(est)-float64(card)) / float64(card)
+			t.Logf("Precision %d, real cardinality %d, computed cardinality %d", precision,
+				card, est)
+			// allow 5 times the standard error
+			if relerr > 5*1.04/math.Sqrt(float64(hpl.numslot)) {
+				t.Fatalf("Too large error %f for cardinality %d", relerr, card)
+			}
+		}
+		bytes := hpl.serialize()
+		ok, hpl2 := deserialize("1", 0, bytes)
+		if !ok || hpl2.algo != HLLPP || !reflect.DeepEqual(allslots(hpl), allslots(hpl2)) {
+			t.Fatalf("Serialization failed for precision %d", precision)
+		}
+	}
+}
+
+func TestHyperLogSparse(t *testing.T) {
+	rand.Seed(42)
+	hpl := newHyperLog("1", 0, 14, HLLPP)
+	mp := make(map[uint32]uint32)
+	i := 0
+	for i < 1000 {
+		i++
+		v := rand.Uint64()
+		hpl.addhash(v)
+		idx := uint32(v >> 50)
+		leadzs := uint32(bits.LeadingZeros64(v<<14)) + 1
+		if mp[idx] < leadzs {
+			mp[idx] = leadzs
+		}
+	}
+	if hpl.isdense() || hpl.slot != nil {
+		t.Fatal("Log with few entries must stay sparse")
+	}
+	for idx, val := range mp {
+		if hpl.getslot(idx) != val {
+			t.Fatalf("Incorrect value at slot %d", idx)
+		}
+	}
+	bytes := hpl.serialize()
+	ok, hpl2 := deserialize("1", 0, bytes)
+	if !ok || hpl2.isdense() || !reflect.DeepEqual(allslots(hpl), allslots(hpl2)) {
+		t.Fatal("Sparse serialization failed")
+	}
+	t.Logf("Sparse serialized size %d for %d non-zero slots", len(bytes), hpl.numnonzeroslot)
+	for i < 100000 {
+		i++
+		hpl.addhash(rand.Uint64())
+	}
+	if !hpl.isdense() || hpl.sparse != nil {
+		t.Fatal("Log not promoted to dense")
+	}
+	if hpl.count_cardinality() == 0 {
+		t.Fatal("Cardinality lost during promotion")
+	}
+}
+
+func TestHyperLogMerge(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	all := newHyperLog("all", 0, 12, HLLPP)
+	hc.AddLog("h1", nil, 0, 12, HLLPP)
+	hc.AddLog("h2", nil, 0, 12, HLLPP)
+	i := 0
+	for i < 20000 {
+		entry := []byte(fmt.Sprintf("entry%d", i))
+		if i%2 == 0 {
+			hc.AddLog("h1", entry, 0, 12, HLLPP)
+		} else {
+			hc.AddLog("h2", entry, 0, 12, HLLPP)
+		}
+		all.addhash(all.hash(entry))
+		i++
+	}
+	if err := hc.Merge("day", []string{"h1", "h2", "missing"}); err != nil {
+		t.Fatal(err)
+	}
+	if hc.GetCardinality("day") != all.count_cardinality() {
+		t.Fatalf("Merged cardinality %d, expected %d", hc.GetCardinality("day"),
+			all.count_cardinality())
+	}
+	hc.AddLog("other", []byte("x"), 0, 10, HLLPP)
+	if hc.Merge("day", []string{"other"}) != ErrIncompatibleLogs {
+		t.Fatal("Merge of logs with different precision must fail")
+	}
+	if hc.Merge("new", []string{"h1", "other"}) != ErrIncompatibleLogs {
+		t.Fatal("Merge of logs with different precision must fail")
+	}
+}
+
+func TestHyperLogUnion(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	keys := []string{"s1", "s2", "s3"}
+	i := 0
+	for i < 30000 {
+		hc.AddLog(keys[i%3], []byte(fmt.Sprintf("entry%d", i%20000)), 0, 0, CLASSIC)
+		i++
+	}
+	card, err := hc.GetUnionCardinality(append(keys, "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc.GetCardinality("missing") != 0 {
+		t.Fatal("Union cardinality must not create logs")
+	}
+	if err := hc.Merge("all", keys); err != nil {
+		t.Fatal(err)
+	}
+	if card != hc.GetCardinality("all") {
+		t.Fatalf("Union cardinality %d, merged cardinality %d", card, hc.GetCardinality("all"))
+	}
+	hc.AddLog("other", nil, 0, 0, HLLPP)
+	if _, err := hc.GetUnionCardinality([]string{"s1", "other"}); err != ErrIncompatibleLogs {
+		t.Fatal("Union of logs with different algorithm must fail")
+	}
+}
+
+func TestHyperLogIntersection(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	// a has entries 0..29999, b has entries 20000..39999
+	i := 0
+	for i < 40000 {
+		entry := []byte(fmt.Sprintf("entry%d", i))
+		if i < 30000 {
+			hc.AddLog("a", entry, 0, 14, HLLPP)
+		}
+		if i >= 20000 {
+			hc.AddLog("b", entry, 0, 14, HLLPP)
+		}
+		i++
+	}
+	inter, bound, err := hc.GetIntersectionCardinality("a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(float64(inter)-10000) > 3*float64(bound) {
+		t.Fatalf("Intersection %d with error bound %d, expected 10000", inter, bound)
+	}
+	diff, bound, err := hc.GetDifferenceCardinality("a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(float64(diff)-20000) > 3*float64(bound) {
+		t.Fatalf("Difference %d with error bound %d, expected 20000", diff, bound)
+	}
+	diff, _, _ = hc.GetDifferenceCardinality("a", "missing")
+	if diff != hc.GetCardinality("a") {
+		t.Fatal("Difference with a missing log must be the cardinality of the log")
+	}
+	inter, _, _ = hc.GetIntersectionCardinality("a", "missing")
+	if inter != 0 {
+		t.Fatal("Intersection with a missing log must be zero")
+	}
+}
+
+func TestHyperLogExportImport(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	if _, ok := hc.ExportLog("missing"); ok {
+		t.Fatal("Export of a missing log must fail")
+	}
+	i := 0
+	for i < 20000 {
+		hc.AddLog("src", []byte(fmt.Sprintf("entry%d", i)), 0, 12, HLLPP)
+		if i < 10 {
+			hc.AddLog("dst", []byte(fmt.Sprintf("other%d", i)), 0, 12, HLLPP)
+		}
+		i++
+	}
+	sketch, ok := hc.ExportLog("src")
+	if !ok {
+		t.Fatal("Export failed")
+	}
+	if err := hc.ImportLog("copy", sketch, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if hc.GetCardinality("copy") != hc.GetCardinality("src") {
+		t.Fatal("Imported log differs from the exported log")
+	}
+	// replacing a sparse log with a dense sketch and back
+	if err := hc.ImportLog("dst", sketch, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if hc.GetCardinality("dst") != hc.GetCardinality("src") {
+		t.Fatal("Replaced log differs from the exported log")
+	}
+	small := newHyperLog("", 0, 12, HLLPP)
+	small.addhash(small.hash([]byte("x")))
+	if err := hc.ImportLog("dst", small.serialize(), 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if hc.GetCardinality("dst") != 1 {
+		t.Fatal("Replacing a dense log with a sparse sketch failed")
+	}
+	if err := hc.ImportLog("dst", sketch, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	hc.Merge("merged", []string{"src"})
+	if err := hc.ImportLog("merged", small.serialize(), 0, true); err != nil {
+		t.Fatal(err)
+	}
+	if hc.GetCardinality("dst") != hc.GetCardinality("merged") {
+		t.Fatal("Merging an imported sketch failed")
+	}
+	if hc.ImportLog("dst", []byte{0xfe, 12}, 0, true) != ErrInvalidSketch {
+		t.Fatal("Import of an invalid sketch must fail")
+	}
+	if hc.ImportLog("dst", newHyperLog("", 0, 10, HLLPP).serialize(), 0, true) !=
+		ErrIncompatibleLogs {
+		t.Fatal("Import of a sketch with different precision must fail")
+	}
+}
+
+func TestHyperLogTTL(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	if hc.TTL("key") != -2 || hc.Exists("key") {
+		t.Fatal("Missing log must have ttl -2")
+	}
+	hc.AddLog("key", nil, 0, 0, CLASSIC)
+	if hc.TTL("key") != -1 || !hc.Exists("key") {
+		t.Fatal("Log without expiry must have ttl -1")
+	}
+	if succeeded(hc.Persist("key")) {
+		t.Fatal("Persist of a log without expiry must fail")
+	}
+	hc.UpdateExpiry("key", 1000)
+	if ttl := hc.TTL("key"); ttl < 999 || ttl > 1000 {
+		t.Fatalf("Unexpected ttl %d", ttl)
+	}
+	if numlogs, numexpiry := hc.NumLogs(); numlogs != 1 || numexpiry != 1 {
+		t.Fatalf("Unexpected number of logs %d, %d", numlogs, numexpiry)
+	}
+	if !succeeded(hc.Persist("key")) || hc.TTL("key") != -1 {
+		t.Fatal("Persist failed")
+	}
+	if _, numexpiry := hc.NumLogs(); numexpiry != 0 {
+		t.Fatal("Persisted log still in the expiry buckets")
+	}
+}
+
+func TestHyperLogRedis(t *testing.T) {
+	// GET of a key in Redis after PFADD key a b c
+	redisdata, _ := hex.DecodeString("48594c4c01000000030000000000000060f38050b1844bfb80425a")
+	ok, imp := deserializeRedis("key", redisdata)
+	if !ok {
+		t.Fatal("Failed to decode the Redis sparse encoding")
+	}
+	hpl := newHyperLog("key", 0, 0, REDIS)
+	for _, entry := range []string{"a", "b", "c"} {
+		hpl.addhash(hpl.hash([]byte(entry)))
+	}
+	if !reflect.DeepEqual(allslots(imp), allslots(hpl)) {
+		t.Fatal("Redis hashing differs")
+	}
+	if !bytes.Equal(hpl.redisSerialize()[16:], redisdata[16:]) {
+		t.Fatal("Redis sparse encoding differs")
+	}
+	i := 0
+	for i < 20000 {
+		hpl.addhash(hpl.hash([]byte(fmt.Sprintf("entry%d", i))))
+		i++
+	}
+	dense := hpl.redisSerialize()
+	if len(dense) != rEDISDENSELEN || dense[4] != rEDISDENSE {
+		t.Fatal("Redis dense encoding expected")
+	}
+	ok, imp = deserializeRedis("key", dense)
+	if !ok || !reflect.DeepEqual(allslots(imp), allslots(hpl)) {
+		t.Fatal("Redis dense encoding failed")
+	}
+
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	if err := hc.ImportRedisLog("key", redisdata, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	hc.AddLog("key", []byte("a"), 0, 0, REDIS)
+	if hc.GetCardinality("key") != 3 {
+		t.Fatal("Imported Redis log must keep counting the same items")
+	}
+	if _, err := hc.ExportRedisLog("missing"); err != ErrLogNotExists {
+		t.Fatal("Export of a missing log must fail")
+	}
+	hc.AddLog("other", nil, 0, 14, HLLPP)
+	if _, err := hc.ExportRedisLog("other"); err != ErrNotRedisLog {
+		t.Fatal("Export of a log not using the redis algorithm must fail")
+	}
+	if hc.ImportRedisLog("key", redisdata[:20], 0, true) != ErrInvalidSketch {
+		t.Fatal("Import of a truncated Redis log must fail")
+	}
+}
+
+func TestHyperLogEstimate(t *testing.T) {
+	hpl := newHyperLog("1", 0, 0, CLASSIC)
+	hpl.addhash(hpl.hash([]byte("a")))
+	est := hpl.estimate(nil)
+	if est.Estimator != LINEARCOUNTING || est.Cardinality != 1 {
+		t.Fatalf("Unexpected estimate %+v", est)
+	}
+	if est.StdError != 1.04/16 {
+		t.Fatalf("Unexpected standard error %f", est.StdError)
+	}
+	i := 0
+	for i < 100000 {
+		hpl.addhash(hpl.hash([]byte(fmt.Sprintf("entry%d", i))))
+		i++
+	}
+	est = hpl.estimate(nil)
+	if est.Estimator != RAW || est.Lower >= est.Cardinality || est.Upper <= est.Cardinality {
+		t.Fatalf("Unexpected estimate %+v", est)
+	}
+	delta := float64(est.Cardinality) * est.StdError * 1.96
+	if math.Abs(float64(est.Upper-est.Lower)-2*delta) > 3 {
+		t.Fatalf("Unexpected confidence interval %+v", est)
+	}
+	hpp := newHyperLog("2", 0, 14, HLLPP)
+	for _, val := range []uint64{1 << 60, 1 << 61} {
+		hpp.addhash(val)
+	}
+	if est = hpp.estimate(nil); est.Estimator != LINEARCOUNTING || est.Cardinality != 2 {
+		t.Fatalf("Unexpected estimate %+v", est)
+	}
+}
+
+func TestHyperLogEstimators(t *testing.T) {
+	names := []string{"default", "loglogbeta", "ertl", "mle"}
+	for _, name := range names {
+		est, ok := GetEstimator(name)
+		if !ok {
+			t.Fatalf("Estimator %s not found", name)
+		}
+		if card := newHyperLog("1", 0, 0, CLASSIC).estimate(est).Cardinality; card != 0 {
+			t.Fatalf("Estimator %s gave %d for an empty log", name, card)
+		}
+	}
+	if _, ok := GetEstimator("unknown"); ok {
+		t.Fatal("Unknown estimator must not be found")
+	}
+	// around 2.5 times the number of slots the classic estimator switches
+	// from linear counting to the raw estimate
+	for _, algo := range []uint8{CLASSIC, HLLPP} {
+		for _, card := range []int{100, 640, 1000, 10000} {
+			hpl := newHyperLog("1", 0, 8, algo)
+			rng := rand.New(rand.NewSource(int64(card)))
+			for i := 0; i < card; i++ {
+				hpl.addhash(rng.Uint64() >> (64 - hpl.hashbits))
+			}
+			for _, name := range names[1:] {
+				est, _ := GetEstimator(name)
+				e := hpl.estimate(est)
+				if math.Abs(float64(e.Cardinality)-float64(card)) > 3*e.StdError*float64(card) {
+					t.Fatalf("Estimator %s gave %d for %d", name, e.Cardinality, card)
+				}
+			}
+		}
+	}
+	if !SetDefaultEstimator("mle") || SetDefaultEstimator("unknown") {
+		t.Fatal("Setting the default estimator failed")
+	}
+	defer SetDefaultEstimator("default")
+	if est := newHyperLog("1", 0, 0, CLASSIC).estimate(nil); est.Estimator != MLE {
+		t.Fatalf("Unexpected estimator %s", est.Estimator)
+	}
+}
+
+func TestHyperLogHashes(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	items := make([][]byte, 1000)
+	for i := range items {
+		items[i] = []byte(fmt.Sprintf("item%d", i))
+	}
+	for _, algo := range []uint8{CLASSIC, HLLPP, REDIS} {
+		itemkey := fmt.Sprintf("items%d", algo)
+		hashkey := fmt.Sprintf("hashes%d", algo)
+		hc.AddLog(itemkey, nil, 0, 0, algo)
+		hc.AddLog(hashkey, nil, 0, 0, algo)
+		hc.AddMLog(itemkey, items, 0)
+		hpl := newHyperLog("", 0, 0, algo)
+		hashes := make([]uint64, len(items))
+		for i, item := range items {
+			hashes[i] = hpl.hash(item)
+		}
+		if changed, err := hc.AddHashes(hashkey, hashes, 0); err != nil || !changed {
+			t.Fatalf("Adding hashes failed %v", err)
+		}
+		a, _ := hc.ExportLog(itemkey)
+		b, _ := hc.ExportLog(hashkey)
+		if !bytes.Equal(a, b) {
+			t.Fatalf("Hashed items differ from the items for algorithm %d", algo)
+		}
+		if changed, _ := hc.AddHashes(hashkey, hashes[:10], 0); changed {
+			t.Fatal("Adding the same hashes must not change the log")
+		}
+	}
+	if _, err := hc.AddHashes("classic", []uint64{1, 1 << 32}, 0); err != ErrHashWidth {
+		t.Fatal("A 64 bit hash must be rejected for the classic algorithm")
+	}
+	if hc.Exists("classic") {
+		t.Fatal("Rejected hashes must not create the log")
+	}
+	hc.AddLog("pp", nil, 0, 14, HLLPP)
+	if _, err := hc.AddHashes("pp", []uint64{1 << 63, 1 << 32}, 0); err != nil {
+		t.Fatal("A 64 bit hash must be accepted for the hllpp algorithm")
+	}
+}
+
+func TestHyperLogSalt(t *testing.T) {
+	key := make([]byte, 16)
+	data := make([]byte, 15)
+	for i := range key {
+		key[i] = byte(i)
+		if i < len(data) {
+			data[i] = byte(i)
+		}
+	}
+	// reference vectors of SipHash-2-4
+	if siphash(key, nil) != 0x726fdb47dd0e0e31 || siphash(key, data) != 0xa129ca6149be45e5 {
+		t.Fatal("Unexpected siphash")
+	}
+
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	salt1 := []byte("0123456789abcdef")
+	salt2 := []byte("fedcba9876543210")
+	items := make([][]byte, 100)
+	for i := range items {
+		items[i] = []byte(fmt.Sprintf("item%d", i))
+	}
+	hc.AddLog("plain", nil, 0, 0, CLASSIC)
+	hc.AddMLog("plain", items, 0)
+	for _, k := range []string{"a", "b"} {
+		if err := hc.AddKeyedLog(k, 0, 0, CLASSIC, salt1); err != nil {
+			t.Fatal(err)
+		}
+		hc.AddMLog(k, items, 0)
+	}
+	hc.AddKeyedLog("c", 0, 0, CLASSIC, salt2)
+	hc.AddMLog("c", items, 0)
+	plain, _ := hc.ExportLog("plain")
+	salted, _ := hc.ExportLog("a")
+	if bytes.Equal(plain, salted) {
+		t.Fatal("Salted log must hash the items differently")
+	}
+	if bytes.Contains(salted, salt1) {
+		t.Fatal("Exported sketch must not carry the salt")
+	}
+	if card := hc.GetCardinality("a"); card < 80 || card > 120 {
+		t.Fatalf("Unexpected cardinality %d", card)
+	}
+	if err := hc.Merge("b", []string{"a"}); err != nil {
+		t.Fatal("Logs with the same salt must merge")
+	}
+	if card, _ := hc.GetUnionCardinality([]string{"a", "b"}); card != hc.GetCardinality("a") {
+		t.Fatalf("Unexpected union cardinality %d", card)
+	}
+	if hc.Merge("c", []string{"a"}) != ErrIncompatibleLogs ||
+		hc.Merge("plain", []string{"a"}) != ErrIncompatibleLogs {
+		t.Fatal("Logs with different salts must not merge")
+	}
+	if err := hc.ImportLog("b", salted, 0, false); err != nil {
+		t.Fatal("Sketch of a log must import into a log with the same salt")
+	}
+	if hc.AddKeyedLog("a", 0, 0, CLASSIC, salt2) != ErrSaltMismatch ||
+		hc.AddKeyedLog("d", 0, 0, CLASSIC, salt1[:8]) != ErrInvalidSalt ||
+		hc.AddKeyedLog("d", 0, 0, REDIS, salt1) != ErrInvalidLogParams {
+		t.Fatal("Invalid salts must be rejected")
+	}
+	if _, err := hc.AddHashes("a", []uint64{1}, 0); err != ErrSaltedLog {
+		t.Fatal("Salted log must not accept hashes")
+	}
+
+	if hc.SetNamespaceSalt("tenant", salt1[:4]) != ErrInvalidSalt {
+		t.Fatal("Invalid namespace salt must be rejected")
+	}
+	hc.SetNamespaceSalt("tenant", salt1)
+	hc.AddMLog("tenant:x", items, 0)
+	ns, _ := hc.ExportLog("tenant:x")
+	if !bytes.Equal(ns, salted) {
+		t.Fatal("Log must use the salt of its namespace")
+	}
+	if _, err := hc.AddHashes("tenant:y", []uint64{1}, 0); err != ErrSaltedLog {
+		t.Fatal("Log in a salted namespace must not accept hashes")
+	}
+	hc.AddLog("tenant:r", nil, 0, 0, REDIS)
+	hc.AddMLog("tenant:r", items, 0)
+	if _, err := hc.ExportRedisLog("tenant:r"); err != nil {
+		t.Fatal("Redis log must not be salted")
+	}
+
+	slot := murmur3_32([]byte("a"), sEED) & hc.hslot
+	hpl := hc.hllmaps[slot].getLog("a")
+	ok, hpl2 := deserializeRecord("a", 0, hpl.serializeRecord())
+	if !ok || !bytes.Equal(hpl2.salt, salt1) || !reflect.DeepEqual(allslots(hpl), allslots(hpl2)) {
+		t.Fatal("Stored record must keep the salt")
+	}
+	ok, hpl2 = deserializeRecord("plain", 0, plain)
+	if !ok || hpl2.salt != nil {
+		t.Fatal("Record without salt must decode")
+	}
+}
+
+func TestHyperLogRecord(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	salt := []byte("0123456789abcdef")
+	logs := []*hyperlog{newHyperLog("", 0, 0, CLASSIC), newHyperLog("", 0, 12, CLASSIC),
+		newHyperLog("", 0, 14, HLLPP), newHyperLog("", 0, 0, REDIS),
+		newHyperLog("", 0, 10, HLLPP)}
+	logs[4].salt = salt
+	for i, hpl := range logs {
+		for j := 0; j < 2000; j++ {
+			hpl.addhash(hpl.hash([]byte(fmt.Sprintf("entry%d", j))))
+		}
+		hpl.ctime, hpl.mtime = 1500000000+uint64(i), 1600000000+uint64(i)
+		record := hpl.serializeRecord()
+		if record[0] != rECMARKER || record[1] != rECVERSION || record[2] != hpl.algo ||
+			record[3] != hpl.precision {
+			t.Fatalf("Unexpected record header %v", record[:rECHDRLEN])
+		}
+		ok, hpl2 := deserializeRecord("", 0, record)
+		if !ok || !hpl.compatible(hpl2) || !reflect.DeepEqual(allslots(hpl), allslots(hpl2)) {
+			t.Fatalf("Record of log %d didn't decode", i)
+		}
+		if hpl2.ctime != hpl.ctime || hpl2.mtime != hpl.mtime {
+			t.Fatalf("Times of log %d not restored from record", i)
+		}
+		// records of version 1 have no times
+		v1 := append(append([]byte(nil), record[:rECHDRLEN]...), record[rECHDRLEN+rECTIMESLEN:]...)
+		v1[1] = 1
+		ok, hpl2 = deserializeRecord("", 0, v1)
+		if !ok || !reflect.DeepEqual(allslots(hpl), allslots(hpl2)) || hpl2.ctime != 0 ||
+			hpl2.mtime != 0 {
+			t.Fatalf("Version 1 record of log %d didn't decode", i)
+		}
+		// records without the header
+		legacy := hpl.serialize()
+		if hpl.salt != nil {
+			legacy = append(append([]byte{sALTMARKER}, salt...), legacy...)
+		}
+		oldkey := fmt.Sprintf("old%d", i)
+		newkey := fmt.Sprintf("new%d", i)
+		if hc.Process(oldkey, 0, legacy) != nil || hc.Process(newkey, 0, record) != nil {
+			t.Fatalf("Records of log %d must be restored", i)
+		}
+		if hc.GetCardinality(oldkey) != hc.GetCardinality(newkey) {
+			t.Fatalf("Old and new records of log %d differ", i)
+		}
+
+		bad := append([]byte(nil), record...)
+		bad[1] = rECVERSION + 1
+		if ok, _ = deserializeRecord("", 0, bad); ok {
+			t.Fatal("Record of an unknown version must not decode")
+		}
+		bad[1] = rECVERSION
+		bad[5]++
+		if ok, _ = deserializeRecord("", 0, bad); ok {
+			t.Fatal("Record with another seed must not decode")
+		}
+		bad[5]--
+		bad[4] = (bad[4] + 1) % (hASHSIPHASH + 1)
+		if ok, _ = deserializeRecord("", 0, bad); ok {
+			t.Fatal("Record with another hash must not decode")
+		}
+		bad[4] = record[4]
+		bad[3]++
+		if ok, _ = deserializeRecord("", 0, bad); ok {
+			t.Fatal("Record with another precision must not decode")
+		}
+	}
+}
+
+func TestHyperLogWal(t *testing.T) {
+	dir := t.TempDir()
+	waldir := filepath.Join(dir, "wal")
+	wal, err := hllstore.OpenWal(waldir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "first.db"), wal, true, time.Hour)
+	defer hc.Shutdown()
+	var items [][]byte
+	var hashes []uint64
+	for i := 0; i < 3000; i++ {
+		items = append(items, []byte(fmt.Sprintf("item%d", i)))
+		hashes = append(hashes, uint64(murmur3_32([]byte(fmt.Sprintf("hash%d", i)), 7)))
+	}
+	salt := []byte("0123456789abcdef")
+	hc.AddMLog("m", items, 0)
+	hc.AddLog("p", nil, 3600, 12, HLLPP)
+	hc.AddMLog("p", items[:500], 0)
+	hc.Persist("p")
+	hc.AddKeyedLog("s", 0, 0, CLASSIC, salt)
+	hc.AddMLog("s", items[:100], 0)
+	hc.AddHashes("h", hashes, 0)
+	hc.Merge("merged", []string{"m", "h"})
+	sketch, _ := hc.ExportLog("h")
+	hc.AddMLog("r", items, 0)
+	hc.ImportLog("r", sketch, 0, false)
+	hc.AddMLog("d", items[:10], 0)
+	hc.DelLog("d")
+	hc.AddLog("e", nil, 0, 0, CLASSIC)
+	hc.UpdateExpiry("e", 500)
+	hc.AddMLog("o", items[:200], 0)
+	hc.Rename("o", "renamed", true)
+	hc.Copy("m", "copied", false)
+	wal.Close()
+
+	// a store without any of the changes, everything comes from the wal
+	wal, err = hllstore.OpenWal(waldir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := hllstore.NewBoltStore(dir, "second.db")
+	hc2 := NewHllContainerWithWal(16, store, wal, false, time.Hour)
+	defer hc2.Shutdown()
+	for _, key := range []string{"m", "p", "s", "h", "merged", "r", "e", "renamed", "copied"} {
+		if !hc2.Exists(key) || hc.GetCardinality(key) != hc2.GetCardinality(key) {
+			t.Fatalf("Log %s not replayed", key)
+		}
+		exp, _ := hc.ExportLog(key)
+		exp2, _ := hc2.ExportLog(key)
+		if !bytes.Equal(exp, exp2) {
+			t.Fatalf("Log %s replayed with different slots", key)
+		}
+	}
+	if hc2.Exists("d") || hc2.Exists("o") {
+		t.Fatal("Deleted and renamed logs must stay deleted")
+	}
+	if hc2.TTL("p") != -1 || hc2.TTL("e") < 490 || hc2.TTL("e") > 500 {
+		t.Fatalf("Unexpected replayed expiry %d %d", hc2.TTL("p"), hc2.TTL("e"))
+	}
+	slot := murmur3_32([]byte("s"), sEED) & hc2.hslot
+	if !bytes.Equal(hc2.hllmaps[slot].getLog("s").salt, salt) {
+		t.Fatal("Replayed log must keep its salt")
+	}
+	segments, _ := os.ReadDir(waldir)
+	if len(segments) != 1 {
+		t.Fatalf("Replayed segments must be truncated, found %d", len(segments))
+	}
+
+	hc2.AddMLog("new", items[:50], 0)
+	hc2.checkpoint()
+	if _, _, err = store.Get("new"); err != nil {
+		t.Fatal("Checkpoint must write the changed logs to the store")
+	}
+	segments, _ = os.ReadDir(waldir)
+	if len(segments) != 1 {
+		t.Fatalf("Checkpoint must truncate the wal, found %d segments", len(segments))
+	}
+	// the queued delete of the renamed log is written before truncating
+	hc2.Rename("new", "moved", false)
+	hc2.checkpoint()
+	if _, _, err = store.Get("new"); err == nil {
+		t.Fatal("Checkpoint must delete the renamed log from the store")
+	}
+	if _, _, err = store.Get("moved"); err != nil {
+		t.Fatal("Checkpoint must write the renamed log to the store")
+	}
+	wal.Close()
+}
+
+func TestHyperLogShutdown(t *testing.T) {
+	dir := t.TempDir()
+	store := hllstore.NewBoltStore(dir, "shutdown.db")
+	hc := NewHllContainer(16, store)
+	for i := 0; i < 3000; i++ {
+		hc.AddLog(fmt.Sprintf("key%d", i), []byte("item"), 0, 0, CLASSIC)
+	}
+	hc.DelLog("key0")
+	if dropped := hc.ShutdownTimeout(10 * time.Second); dropped != 0 {
+		t.Fatalf("Shutdown dropped %d updates", dropped)
+	}
+	if hc.ShutdownTimeout(0) != 0 {
+		t.Fatal("Shutdown must be done once")
+	}
+	store.FlushAndStop()
+	store = hllstore.NewBoltStore(dir, "shutdown.db")
+	hc = NewHllContainer(16, store)
+	if numlogs, _ := hc.NumLogs(); numlogs != 2999 || hc.Exists("key0") {
+		t.Fatalf("Pending updates must be stored on shutdown, restored %d logs", numlogs)
+	}
+	for i := 0; i < 3000; i++ {
+		hc.AddLog(fmt.Sprintf("new%d", i), []byte("item"), 0, 0, CLASSIC)
+	}
+	if dropped := hc.ShutdownTimeout(0); dropped == 0 {
+		t.Fatal("Shutdown must report the dropped updates")
+	}
+	store.FlushAndStop()
+}
+
+// Store whose updates hang until released
+type hangingStore struct {
+	hllstore.HllStore
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (hs *hangingStore) Update(key string, expiry uint64, value []byte) error {
+	select {
+	case hs.entered <- struct{}{}:
+	default:
+	}
+	<-hs.release
+	return hs.HllStore.Update(key, expiry, value)
+}
+
+func TestHyperLogShutdownHanging(t *testing.T) {
+	bolt := hllstore.NewBoltStore(t.TempDir(), "hanging.db")
+	store := &hangingStore{bolt, make(chan struct{}, 1), make(chan struct{})}
+	hc := NewHllContainer(16, store)
+	hc.AddLog("a", []byte("item"), 0, 0, CLASSIC)
+	hc.AddLog("b", []byte("item"), 0, 0, CLASSIC)
+	select {
+	case <-store.entered:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Store update not started")
+	}
+	start := time.Now()
+	if dropped := hc.ShutdownTimeout(100 * time.Millisecond); dropped == 0 {
+		t.Fatal("Shutdown must report the updates of the abandoned workers")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("Shutdown must not wait for hanging workers past the timeout")
+	}
+	close(store.release)
+	bolt.FlushAndStop()
+}
+
+func TestHyperLogStoreErr(t *testing.T) {
+	if NewHllContainer(16, nil).StoreErr() != nil {
+		t.Fatal("Without a store updates are never rejected")
+	}
+	store := hllstore.NewBoltStore(t.TempDir(), "storeerr.db")
+	hc := NewHllContainer(16, store)
+	if hc.StoreErr() != nil {
+		t.Fatal("Healthy store must take updates")
+	}
+	hc.SetMaxPendingUpdates(2)
+	hc.pending.Add(2)
+	if hc.StoreErr() != ErrStoreOverloaded {
+		t.Fatal("Updates must be rejected with too many pending")
+	}
+	hc.pending.Add(-2)
+	if hc.StoreErr() != nil {
+		t.Fatal("Updates must be taken again when the store catches up")
+	}
+	hc.Shutdown()
+	store.FlushAndStop()
+}
+
+func TestHyperLogLazyExpiry(t *testing.T) {
+	dir := t.TempDir()
+	store := hllstore.NewBoltStore(dir, "expiry.db")
+	hc := NewHllContainer(16, store)
+	for i := 0; i < 100; i++ {
+		hc.AddLog("key", []byte(fmt.Sprintf("item%d", i)), 3600, 0, CLASSIC)
+	}
+	hc.AddLog("other", []byte("item"), 3600, 0, CLASSIC)
+	now := nowMillis()
+	hc.setExpiry("key", now)
+	hc.setExpiry("other", now)
+	if hc.GetCardinality("key") != 0 || hc.Exists("key") || hc.TTL("key") != -2 {
+		t.Fatal("Expired log must be treated as absent before the cleanup")
+	}
+	if succeeded(hc.UpdateExpiry("other", 3600)) || succeeded(hc.Persist("other")) {
+		t.Fatal("Expiry of an expired log must not be updated")
+	}
+	hc.AddLog("key", []byte("new"), 0, 0, CLASSIC)
+	if card := hc.GetCardinality("key"); card != 1 {
+		t.Fatalf("Update of an expired log must recreate it, cardinality %d", card)
+	}
+	if hc.TTL("key") != -1 {
+		t.Fatal("Recreated log must not keep the old expiry")
+	}
+	if numlogs, numexpiry := hc.NumLogs(); numlogs != 1 || numexpiry != 0 {
+		t.Fatalf("Expired logs must be removed, %d logs %d with expiry", numlogs, numexpiry)
+	}
+	hc.Shutdown()
+	store.FlushAndStop()
+	store = hllstore.NewBoltStore(dir, "expiry.db")
+	hc = NewHllContainer(16, store)
+	if numlogs, _ := hc.NumLogs(); numlogs != 1 || hc.GetCardinality("key") != 1 {
+		t.Fatalf("Recreated log must replace the expired log in the store, %d logs", numlogs)
+	}
+	hc.Shutdown()
+	store.FlushAndStop()
+}
+
+func TestHyperLogExpiryCleanup(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	now := nowMillis()
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%d", i)
+		hc.AddLog(key, []byte("item"), 3600, 0, CLASSIC)
+		if i%2 == 0 {
+			hc.setExpiry(key, now-uint64(i%7))
+		}
+	}
+	hc.AddLog("persisted", []byte("item"), 3600, 0, CLASSIC)
+	hc.Persist("persisted")
+	hc.DelLog("key1")
+	if _, numexpiry := hc.NumLogs(); numexpiry != 999 {
+		t.Fatalf("Expected 999 logs with expiry, found %d", numexpiry)
+	}
+	if removed := hc.doCleanup(); removed != 500 {
+		t.Fatalf("Expected 500 expired logs removed, removed %d", removed)
+	}
+	if numlogs, numexpiry := hc.NumLogs(); numlogs != 500 || numexpiry != 499 {
+		t.Fatalf("Expected 500 logs, 499 with expiry, found %d, %d", numlogs, numexpiry)
+	}
+	for _, hm := range hc.hllmaps {
+		for i, hlog := range hm.expiries {
+			if hlog.expidx != i || (i > 0 && hm.expiries[(i-1)/2].expiry > hlog.expiry) {
+				t.Fatal("Expiry heap is out of order")
+			}
+		}
+		next := atomic.LoadUint64(&hm.nextexpiry)
+		if len(hm.expiries) > 0 && next != hm.expiries[0].expiry ||
+			len(hm.expiries) == 0 && next != 0 {
+			t.Fatal("Next expiry of the map must be the earliest in its heap")
+		}
+	}
+}
+
+func TestHyperLogPExpire(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	hc.AddLog("key", []byte("item"), 0, 0, CLASSIC)
+	if !succeeded(hc.PExpire("key", 1500)) {
+		t.Fatal("Expiry in milliseconds must be set")
+	}
+	if pttl := hc.PTTL("key"); pttl <= 1000 || pttl > 1500 || hc.TTL("key") != 2 {
+		t.Fatalf("Unexpected ttl %d ms, %d s", pttl, hc.TTL("key"))
+	}
+	at := nowMillis() + 100
+	if !succeeded(hc.PExpireAt("key", at)) {
+		t.Fatal("Expiry at a unix time in milliseconds must be set")
+	}
+	if expiry, _ := hc.GetExpiry("key"); expiry != at/1000+1 && expiry != at/1000 {
+		t.Fatalf("Expiry must be rounded up to seconds, found %d for %d", expiry, at)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if hc.Exists("key") || hc.PTTL("key") != -2 {
+		t.Fatal("Log must expire with millisecond granularity")
+	}
+	hc.AddLog("key", []byte("item"), 0, 0, CLASSIC)
+	if !succeeded(hc.UpdateExpiry("key", 1<<63)) || hc.PTTL("key") <= 0 {
+		t.Fatal("Huge expiry must be capped")
+	}
+	if expiry, _ := hc.getExpiry("key"); expiry != hllstore.MaxExpiry {
+		t.Fatalf("Expiry must be capped at %d, found %d", hllstore.MaxExpiry, expiry)
+	}
+
+	// write ahead log records without wALMILLIS have the expiry in seconds
+	legacy := walExpiry("key", 1234)
+	legacy[0] = wALEXPIRY
+	if wop, ok := decodeWalOp(legacy); !ok || wop.op != wALEXPIRY || wop.expiry != 1234000 {
+		t.Fatal("Expiry of legacy records must be read in seconds")
+	}
+	if wop, ok := decodeWalOp(walExpiry("key", 1234)); !ok || wop.op != wALEXPIRY ||
+		wop.expiry != 1234 {
+		t.Fatal("Expiry of records must be read in milliseconds")
+	}
+}
+
+// Writes to logs while a million logs expire together, reports the slowest
+// write besides the mean
+func BenchmarkHyperLogExpiry(b *testing.B) {
+	const numexpiring = 1000000
+	hc := NewHllContainer(1024, nil)
+	defer hc.Shutdown()
+	for i := 0; i < numexpiring; i++ {
+		hc.AddLog(fmt.Sprintf("expiring%d", i), []byte("item"), 1, 0, CLASSIC)
+	}
+	item := []byte("item")
+	// until the logs expired, the cleanup of the container removes them
+	time.Sleep(time.Until(time.Unix(time.Now().Unix()+2, 0)))
+	b.ResetTimer()
+	var slowest time.Duration
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		hc.AddLog(fmt.Sprintf("key%d", i%10000), item, 3600, 0, CLASSIC)
+		if elapsed := time.Since(start); elapsed > slowest {
+			slowest = elapsed
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(slowest.Nanoseconds()), "max-ns/op")
+	for hc.doCleanup() > 0 {
+	}
+	if numlogs, _ := hc.NumLogs(); numlogs > 10000 {
+		b.Fatalf("Expired logs not removed, %d logs left", numlogs)
+	}
+}
+
+func TestHyperLogExpireAt(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	if _, ok := hc.GetExpiry("key"); ok || succeeded(hc.ExpireAt("key", 4102444800)) {
+		t.Fatal("Missing log must not have an expiry")
+	}
+	hc.AddLog("key", []byte("item"), 0, 0, CLASSIC)
+	if expiry, ok := hc.GetExpiry("key"); !ok || expiry != 0 || hc.TTL("key") != -1 {
+		t.Fatal("Log must have no expiry")
+	}
+	if succeeded(hc.ExpireAt("key", 0)) || !succeeded(hc.ExpireAt("key", 4102444800)) {
+		t.Fatal("Expiry must be set at a positive unix time")
+	}
+	if expiry, _ := hc.GetExpiry("key"); expiry != 4102444800 || hc.TTL("key") <= 0 {
+		t.Fatalf("Expected expiry 4102444800, found %d", expiry)
+	}
+	if !succeeded(hc.Persist("key")) || hc.TTL("key") != -1 {
+		t.Fatal("Persist must remove the expiry")
+	}
+	if !succeeded(hc.ExpireAt("key", uint64(time.Now().Unix())-10)) || hc.Exists("key") {
+		t.Fatal("Expiry in the past must expire the log")
+	}
+}
+
+func TestHyperLogKeyNotExists(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	if _, err := hc.GetLogEstimate("key", nil); err != ErrLogNotExists {
+		t.Fatal("Missing log must not be estimated")
+	}
+	if succeeded(hc.DelLog("key")) || succeeded(hc.UpdateExpiry("key", 100)) {
+		t.Fatal("Missing log must not be deleted or expired")
+	}
+	if hc.CreateLog("key", 0, 0, HLLPP, nil) != nil {
+		t.Fatal("Failed to create log")
+	}
+	if hc.CreateLog("key", 0, 0, CLASSIC, nil) != ErrLogExists ||
+		hc.CreateLog("other", 0, 30, CLASSIC, nil) != ErrInvalidLogParams {
+		t.Fatal("Existing log and invalid parameters must be rejected")
+	}
+	hc.AddLog("key", []byte("item"), 0, 0, CLASSIC)
+	if est, err := hc.GetLogEstimate("key", nil); err != nil || est.Cardinality != 1 {
+		t.Fatal("Existing log must be estimated")
+	}
+	if !succeeded(hc.DelLog("key")) || succeeded(hc.DelLog("key")) || hc.Exists("key") {
+		t.Fatal("Log must be deleted once")
+	}
+}
+
+func TestHyperLogScan(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	for i := 0; i < 500; i++ {
+		hc.AddLog(fmt.Sprintf("user:%d", i), nil, 0, 0, CLASSIC)
+		hc.AddLog(fmt.Sprintf("page:%d", i), nil, 3600, 0, CLASSIC)
+	}
+	hc.setExpiry("user:7", nowMillis())
+	scan := func(hc *HllContainer, pattern string, count int) map[string]int64 {
+		seen := make(map[string]int64)
+		cursor := "0"
+		for calls := 0; ; calls++ {
+			keys, next, err := hc.Scan(cursor, pattern, count)
+			if err != nil || calls >= len(hc.hllmaps) {
+				t.Fatalf("Scan failed: %v, %d keys", err, len(keys))
+			}
+			for _, k := range keys {
+				if _, ok := seen[k.Key]; ok {
+					t.Fatalf("Key %s returned twice", k.Key)
+				}
+				seen[k.Key] = k.TTL
+			}
+			if next == "0" {
+				return seen
+			}
+			cursor = next
+		}
+	}
+	if seen := scan(hc, "", 7); len(seen) != 999 || seen["user:1"] != -1 || seen["page:1"] <= 0 {
+		t.Fatalf("Expected 999 keys with their TTL, scanned %d", len(seen))
+	}
+	if seen := scan(hc, "user:1?", 3); len(seen) != 10 {
+		t.Fatalf("Expected 10 keys matching user:1?, scanned %d", len(seen))
+	}
+	if seen := scan(hc, "page:[1-2]*[^0-8]", 1000); len(seen) != 22 {
+		t.Fatalf("Expected 22 keys matching the class pattern, scanned %d", len(seen))
+	}
+	// a scan walks the keys of a map once whatever the count
+	big := NewHllContainer(4, nil)
+	defer big.Shutdown()
+	for i := 0; i < 100000; i++ {
+		big.AddLog(fmt.Sprintf("key:%d", i), nil, 0, 0, CLASSIC)
+	}
+	if seen := scan(big, "", 10); len(seen) != 100000 {
+		t.Fatalf("Expected 100000 keys, scanned %d", len(seen))
+	}
+	if _, _, err := hc.Scan("x", "", 10); err != ErrInvalidCursor {
+		t.Fatal("Invalid cursor must be rejected")
+	}
+	for _, c := range []struct {
+		pattern, key string
+		match        bool
+	}{
+		{"*", "", true}, {"a*b*c", "aXbYbZc", true}, {"a*b", "ab", true}, {"a?c", "ac", false},
+		{"\\*x", "*x", true}, {"\\*x", "ax", false}, {"[!a]x", "bx", true}, {"[]]", "]", true},
+		{"[a-", "a", false}, {"*[0-9]", "key7", true}, {"k*y", "kex", false},
+	} {
+		if globMatch(c.pattern, c.key) != c.match {
+			t.Fatalf("Pattern %q on %q must give %v", c.pattern, c.key, c.match)
+		}
+	}
+}
+
+func TestHyperLogInfo(t *testing.T) {
+	dir := t.TempDir()
+	store := hllstore.NewBoltStore(dir, "info.db")
+	// a log stored long ago
+	old := newHyperLog("old", 0, 0, CLASSIC)
+	old.addhash(old.hash([]byte("item")))
+	old.ctime, old.mtime = 1500000000, 1500000000
+	if store.Update("old", 0, old.serializeRecord()) != nil || store.Flush() != nil {
+		t.Fatal("Failed to store log")
+	}
+	hc := NewHllContainer(16, store)
+	if _, err := hc.GetLogInfo("key"); err != ErrLogNotExists {
+		t.Fatal("Missing log must have no info")
+	}
+	start := uint64(time.Now().Unix())
+	hc.AddLog("key", []byte("item"), 3600, 12, HLLPP)
+	info, err := hc.GetLogInfo("key")
+	if err != nil || info.Key != "key" || info.Algorithm != HLLPP || info.Precision != 12 ||
+		info.Salted || info.NonZeroSlots != 1 || info.Expiry < start+3600 ||
+		info.Ctime < start || info.Mtime < info.Ctime {
+		t.Fatalf("Unexpected info %+v", info)
+	}
+	slot := murmur3_32([]byte("key"), sEED) & hc.hslot
+	if size := len(hc.hllmaps[slot].getLog("key").serializeRecord()); info.SerializedSize != size {
+		t.Fatalf("Expected serialized size %d, found %d", size, info.SerializedSize)
+	}
+	hc.CreateLog("empty", 0, 0, CLASSIC, nil)
+	if info, _ := hc.GetLogInfo("empty"); info.Persisted || info.NonZeroSlots != 0 {
+		t.Fatalf("Log not written to the store must not be persisted, found %+v", info)
+	}
+	if !succeeded(hc.Persist("key")) {
+		t.Fatal("Failed to persist log")
+	}
+	if info, _ = hc.GetLogInfo("key"); info.Expiry != 0 {
+		t.Fatalf("Expiry must be removed, found %+v", info)
+	}
+
+	if info, _ = hc.GetLogInfo("old"); info.Ctime != 1500000000 || info.Mtime != 1500000000 ||
+		!info.Persisted || info.PendingUpdates != 0 {
+		t.Fatalf("Unexpected info of restored log %+v", info)
+	}
+	hc.AddLog("old", []byte("item"), 0, 0, CLASSIC)
+	if info, _ = hc.GetLogInfo("old"); info.Mtime != 1500000000 {
+		t.Fatal("Adding a counted item must not modify the log")
+	}
+	hc.AddLog("old", []byte("other"), 0, 0, CLASSIC)
+	if info, _ = hc.GetLogInfo("old"); info.Ctime != 1500000000 || info.Mtime < start {
+		t.Fatalf("Adding an item must modify the log only, found %+v", info)
+	}
+	for deadline := time.Now().Add(5 * time.Second); !info.Persisted; {
+		if time.Now().After(deadline) {
+			t.Fatal("Log not written to the store")
+		}
+		time.Sleep(10 * time.Millisecond)
+		info, _ = hc.GetLogInfo("old")
+	}
+	hc.Shutdown()
+	store.FlushAndStop()
+
+	store = hllstore.NewBoltStore(dir, "info.db")
+	hc = NewHllContainer(16, store)
+	defer store.FlushAndStop()
+	defer hc.Shutdown()
+	restored, err := hc.GetLogInfo("old")
+	if err != nil || restored.Ctime != 1500000000 || restored.Mtime != info.Mtime ||
+		restored.NonZeroSlots != 2 {
+		t.Fatalf("Times not restored from the store, found %+v", restored)
+	}
+}
+
+func TestHyperLogRename(t *testing.T) {
+	dir := t.TempDir()
+	store := hllstore.NewBoltStore(dir, "rename.db")
+	hc := NewHllContainer(16, store)
+	salt := []byte("0123456789abcdef")
+	hc.AddKeyedLog("src", 3600, 12, HLLPP, salt)
+	for i := 0; i < 1000; i++ {
+		hc.AddLog("src", []byte(fmt.Sprintf("item%d", i)), 0, 0, CLASSIC)
+	}
+	card := hc.GetCardinality("src")
+	srcinfo, _ := hc.GetLogInfo("src")
+	if hc.Rename("missing", "dst", true) != ErrLogNotExists ||
+		hc.Copy("missing", "dst", true) != ErrLogNotExists || hc.Exists("dst") {
+		t.Fatal("Missing log must not be renamed or copied")
+	}
+	if hc.Copy("src", "src", true) != ErrSameLog || hc.Rename("src", "src", false) != ErrLogExists ||
+		hc.Rename("src", "src", true) != nil || !hc.Exists("src") {
+		t.Fatal("Unexpected rename or copy of a log to itself")
+	}
+
+	// the copy is independent of the source
+	if hc.Copy("src", "copy", false) != nil || hc.GetCardinality("copy") != card {
+		t.Fatal("Failed to copy log")
+	}
+	for i := 0; i < 1000; i++ {
+		hc.AddLog("copy", []byte(fmt.Sprintf("other%d", i)), 0, 0, CLASSIC)
+	}
+	copycard := hc.GetCardinality("copy")
+	if hc.GetCardinality("src") != card || copycard <= card {
+		t.Fatal("Copied logs must not share slots")
+	}
+	if info, _ := hc.GetLogInfo("copy"); !info.Salted || info.Expiry != srcinfo.Expiry ||
+		info.Algorithm != HLLPP || info.Precision != 12 {
+		t.Fatalf("Copy must keep the parameters and expiry, found %+v", info)
+	}
+
+	hc.AddLog("dst", []byte("item"), 0, 0, CLASSIC)
+	if hc.Rename("src", "dst", false) != ErrLogExists || hc.Copy("src", "dst", false) != ErrLogExists ||
+		hc.GetCardinality("dst") != 1 {
+		t.Fatal("Existing log must not be overwritten")
+	}
+	if hc.Rename("src", "dst", true) != nil || hc.Exists("src") || hc.GetCardinality("dst") != card {
+		t.Fatal("Failed to rename log over an existing log")
+	}
+	info, _ := hc.GetLogInfo("dst")
+	if info.Algorithm != HLLPP || info.Expiry != srcinfo.Expiry || info.Ctime != srcinfo.Ctime {
+		t.Fatalf("Rename must keep the log as it was, found %+v", info)
+	}
+	if numlogs, numexpiry := hc.NumLogs(); numlogs != 2 || numexpiry != 2 {
+		t.Fatalf("Expected 2 logs with expiry, found %d and %d", numlogs, numexpiry)
+	}
+	// the renamed log expires under its new key
+	hc.ExpireAt("dst", uint64(time.Now().Unix())-1)
+	if hc.doCleanup() != 1 || hc.Exists("dst") {
+		t.Fatal("Renamed log must expire")
+	}
+	hc.Rename("copy", "final", false)
+	hc.Shutdown()
+	store.FlushAndStop()
+
+	store = hllstore.NewBoltStore(dir, "rename.db")
+	hc = NewHllContainer(16, store)
+	defer store.FlushAndStop()
+	defer hc.Shutdown()
+	if numlogs, _ := hc.NumLogs(); numlogs != 1 || hc.GetCardinality("final") != copycard {
+		t.Fatalf("Store must have the renamed log only, found %d logs", numlogs)
+	}
+}
+
+func TestHyperLogRenameConcurrent(t *testing.T) {
+	hc := NewHllContainer(16, nil)
+	defer hc.Shutdown()
+	hc.AddLog("src", nil, 0, 0, CLASSIC)
+	hlog := hc.hllmaps[murmur3_32([]byte("src"), sEED)&hc.hslot].getLog("src")
+	hc.Rename("src", "dst", false)
+	if _, _, live := hlog.addhashes([]uint64{12345}); live || hlog.count_cardinality() != 0 {
+		t.Fatal("Renamed log must reject updates")
+	}
+
+	// the items added while renaming are in one of the renamed logs
+	const numitems = 20000
+	all := newHyperLog("", 0, 0, CLASSIC)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < numitems; i++ {
+			item := []byte(fmt.Sprintf("item%d", i))
+			hc.AddMLog("src", [][]byte{item}, 0)
+		}
+		done <- true
+	}()
+	keys := []string{"src"}
+	for renaming := true; renaming; {
+		select {
+		case <-done:
+			renaming = false
+		default:
+			key := fmt.Sprintf("renamed%d", len(keys))
+			if hc.Rename("src", key, false) == nil {
+				keys = append(keys, key)
+			}
+		}
+	}
+	for i := 0; i < numitems; i++ {
+		all.addhash(all.hash([]byte(fmt.Sprintf("item%d", i))))
+	}
+	if card, _ := hc.GetUnionCardinality(keys); card != all.count_cardinality() {
+		t.Fatalf("Updates lost while renaming, %d renames, cardinality %d expected %d",
+			len(keys)-1, card, all.count_cardinality())
+	}
+}
+
+// Store whose first delete fails
+type failingStore struct {
+	hllstore.HllStore
+	failed atomic.Bool
+}
+
+func (fs *failingStore) Delete(key string) error {
+	if fs.failed.CompareAndSwap(false, true) {
+		return hllstore.ErrOverloaded
+	}
+	return fs.HllStore.Delete(key)
+}
+
+func TestHyperLogReplacedDelete(t *testing.T) {
+	bolt := hllstore.NewBoltStore(t.TempDir(), "replaced.db")
+	defer bolt.FlushAndStop()
+	store := &failingStore{HllStore: bolt}
+	hc := NewHllContainer(16, store)
+	hc.AddLog("dst", []byte("item"), 0, 0, CLASSIC)
+	for i := 0; i < 100; i++ {
+		hc.AddLog("src", []byte(fmt.Sprintf("item%d", i)), 0, 0, CLASSIC)
+	}
+	// the delete of the old dst is queued before the new dst
+	hc.Copy("src", "dst", true)
+	if dropped := hc.ShutdownTimeout(10 * time.Second); dropped != 0 {
+		t.Fatalf("Shutdown dropped %d updates", dropped)
+	}
+	hc = NewHllContainer(16, bolt)
+	defer hc.Shutdown()
+	if hc.GetCardinality("dst") != hc.GetCardinality("src") {
+		t.Fatal("Delete of the replaced log must not remove the new log from the store")
+	}
+}
+
+func TestHyperLogWalConcurrentDelete(t *testing.T) {
+	dir := t.TempDir()
+	waldir := filepath.Join(dir, "wal")
+	wal, err := hllstore.OpenWal(waldir, 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "first.db"), wal, true, time.Hour)
+	hc.AddLog("held", nil, 0, 0, CLASSIC)
+	held := hc.hllmaps[murmur3_32([]byte("held"), sEED)&hc.hslot].getLog("held")
+	go hc.DelLog("held")
+	for hc.Exists("held") {
+		time.Sleep(time.Millisecond)
+	}
+	// the delete waits for the sync, an update holding the log meanwhile
+	// must not be recorded after the delete
+	if _, _, live := held.addhashes([]uint64{12345}); live {
+		t.Fatal("Log must reject updates once its delete is recorded")
+	}
+	hc.Shutdown()
+	wal.Close()
+
+	waldir = filepath.Join(dir, "wal2")
+	wal, err = hllstore.OpenWal(waldir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc = NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "second.db"), wal, false, time.Hour)
+	defer hc.Shutdown()
+	const numwriters = 4
+	done := make(chan bool)
+	for w := 0; w < numwriters; w++ {
+		go func(w int) {
+			for i := 0; i < 200; i++ {
+				item := []byte(fmt.Sprintf("item%d-%d", w, i))
+				hc.AddMLog(fmt.Sprintf("key%d", i%4), [][]byte{item}, 0)
+			}
+			done <- true
+		}(w)
+	}
+	for running := numwriters; running > 0; {
+		select {
+		case <-done:
+			running--
+		default:
+			hc.DelLog(fmt.Sprintf("key%d", rand.Intn(4)))
+		}
+	}
+	wal.Close()
+
+	// the replay must end with the logs as they are, a deleted log must not
+	// come back from the changes made to it before its delete
+	wal, err = hllstore.OpenWal(waldir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc2 := NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "third.db"), wal, false, time.Hour)
+	defer hc2.Shutdown()
+	for i := 0; i < 4; i++ {
+		key := fmt.Sprintf("key%d", i)
+		exp, _ := hc.ExportLog(key)
+		exp2, _ := hc2.ExportLog(key)
+		if hc.Exists(key) != hc2.Exists(key) || !bytes.Equal(exp, exp2) {
+			t.Fatalf("Log %s replayed differently", key)
+		}
+	}
+	wal.Close()
+}
+
+func TestHyperLogWalAckNoChanges(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := hllstore.OpenWal(filepath.Join(dir, "wal"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Close()
+	hc := NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "ack.db"), wal, true, time.Hour)
+	defer hc.Shutdown()
+	first := make(chan error, 1)
+	go func() {
+		_, err := hc.AddMLog("key", [][]byte{[]byte("item")}, 0)
+		first <- err
+	}()
+	for !hc.Exists("key") {
+		time.Sleep(time.Millisecond)
+	}
+	// the same item changes nothing, but the change which added it isn't
+	// durable yet, so the add must wait for it
+	second := make(chan error, 1)
+	go func() {
+		_, err := hc.AddMLog("key", [][]byte{[]byte("item")}, 0)
+		second <- err
+	}()
+	select {
+	case <-second:
+		t.Fatal("Add without changes returned before the write ahead log synced")
+	case <-time.After(100 * time.Millisecond):
+	}
+	wal.Sync()
+	for _, done := range []chan error{first, second} {
+		if err := <-done; err != nil {
+			t.Fatalf("Add failed: %s", err)
+		}
+	}
+}
+
+type unflushedStore struct {
+	hllstore.HllStore
+	updated   atomic.Bool
+	failflush atomic.Bool
+}
+
+func (us *unflushedStore) Update(key string, expiry uint64, value []byte) error {
+	us.updated.Store(true)
+	return us.HllStore.Update(key, expiry, value)
+}
+
+func (us *unflushedStore) Flush() error {
+	if us.failflush.Load() {
+		return hllstore.ErrOverloaded
+	}
+	return us.HllStore.Flush()
+}
+
+func TestHyperLogPersistedAfterFlush(t *testing.T) {
+	bolt := hllstore.NewBoltStore(t.TempDir(), "unflushed.db")
+	defer bolt.FlushAndStop()
+	store := &unflushedStore{HllStore: bolt}
+	store.failflush.Store(true)
+	hc := NewHllContainer(16, store)
+	defer hc.Shutdown()
+	hc.AddLog("key", []byte("item"), 0, 0, CLASSIC)
+	for deadline := time.Now().Add(5 * time.Second); !store.updated.Load(); {
+		if time.Now().After(deadline) {
+			t.Fatal("Log not sent to the store")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// sent to the store, but the flushes fail
+	time.Sleep(sTOREFLUSHINTERVAL + 100*time.Millisecond)
+	if info, _ := hc.GetLogInfo("key"); info.Persisted {
+		t.Fatal("Log must not be persisted before the store is flushed")
+	}
+	store.failflush.Store(false)
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		if info, _ := hc.GetLogInfo("key"); info.Persisted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Log not persisted after the store is flushed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHyperLogWalTimes(t *testing.T) {
+	dir := t.TempDir()
+	waldir := filepath.Join(dir, "wal")
+	wal, err := hllstore.OpenWal(waldir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "first.db"), wal, true, time.Hour)
+	defer hc.Shutdown()
+	hc.AddLog("key", []byte("item"), 0, 0, CLASSIC)
+	hc.AddLog("imported", []byte("item"), 0, 0, CLASSIC)
+	for _, key := range []string{"key", "imported"} {
+		hlog := hc.hllmaps[murmur3_32([]byte(key), sEED)&hc.hslot].getLog(key)
+		hlog.ctime = 1500000000
+	}
+	hc.AddLog("key", []byte("other"), 0, 0, CLASSIC)
+	sketch, _ := hc.ExportLog("key")
+	if err = hc.ImportLog("imported", sketch, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	wal.Close()
+
+	// the replayed logs keep their times, not the time of the replay
+	wal, err = hllstore.OpenWal(waldir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Close()
+	hc2 := NewHllContainerWithWal(16, hllstore.NewBoltStore(dir, "second.db"), wal, false, time.Hour)
+	defer hc2.Shutdown()
+	for _, key := range []string{"key", "imported"} {
+		info, _ := hc.GetLogInfo(key)
+		replayed, err := hc2.GetLogInfo(key)
+		if err != nil || replayed.Ctime != 1500000000 || replayed.Mtime != info.Mtime {
+			t.Fatalf("Times of %s not replayed, found %+v", key, replayed)
+		}
+	}
+
+	// records without wALTIMES have no times
+	hlog := newHyperLog("key", 0, 0, CLASSIC)
+	record := walSlots(wALSLOTS, hlog, []uint32{1<<8 | 3})
+	timesoff := wALKEYOFF + len("key") + 3 + 8
+	legacy := append(append([]byte(nil), record[:timesoff]...), record[timesoff+16:]...)
+	legacy[0] &^= wALTIMES
+	if wop, ok := decodeWalOp(legacy); !ok || wop.ctime != 0 || len(wop.entries) != 1 {
+		t.Fatal("Legacy slots record must be read without times")
+	}
+	if wop, ok := decodeWalOp(record); !ok || wop.ctime != hlog.ctime || len(wop.entries) != 1 {
+		t.Fatal("Slots record must carry the creation time")
+	}
+}
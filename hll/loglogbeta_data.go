@@ -0,0 +1,41 @@
+package hll
+
+// Coefficients of the bias correction polynomial of the LogLog-Beta estimator
+// from "LogLog-Beta and More: A New Algorithm for Cardinality Estimation Based
+// on LogLog Counting" (Qin, Kim and Tung), as fitted for each precision by
+// github.com/axiomhq/hyperloglog (MIT license). betaData is indexed by
+// precision - 4. The first coefficient multiplies the number of zero slots ez,
+// the others the powers 1..7 of ln(ez + 1).
+
+var betaData = [][8]float64{
+	// precision 4
+	{-0.582581413904517, -1.935300357560050, 11.079323758035073, -22.131357446444323, 22.505391846630037, -12.000723834917984, 3.220579408194167, -0.342225302271235},
+	// precision 5
+	{-0.7518999460733967, -0.9590030077748760, 5.5997371322141607, -8.2097636999765520, 6.5091254894472037, -2.6830293734323729, 0.5612891113138221, -0.0463331622196545},
+	// precision 6
+	{29.8257900969619634, -31.3287083337725925, -10.5942523036582283, -11.5720125689099618, 3.8188754373907492, -2.4160130328530811, 0.4542208940970826, -0.0575155452020420},
+	// precision 7
+	{2.8102921290820060, -3.9780498518175995, 1.3162680041351582, -3.9252486335805901, 2.0080835753946471, -0.7527151937556955, 0.1265569894242751, -0.0109946438726240},
+	// precision 8
+	{1.00633544887550519, -2.00580666405112407, 1.64369749366514117, -2.70560809940566172, 1.39209980244222598, -0.46470374272183190, 0.07384282377269775, -0.00578554885254223},
+	// precision 9
+	{-0.09415657458167959, -0.78130975924550528, 1.71514946750712460, -1.73711250406516338, 0.86441508489048924, -0.23819027465047218, 0.03343448400269076, -0.00207858528178157},
+	// precision 10
+	{-0.25935400670790054, -0.52598301999805808, 1.48933034925876839, -1.29642714084993571, 0.62284756217221615, -0.15672326770251041, 0.02054415903878563, -0.00112488483925502},
+	// precision 11
+	{-0.432325553856025, -0.108450736399632, 0.609156550741120, -0.0165687801845180, -0.0795829341087617, 0.0471830602102918, -0.00781372902346934, 0.000584268708489995},
+	// precision 12
+	{-0.384979202588598, 0.183162233114364, 0.130396688841854, 0.0704838927629266, -0.0089589397146453, 0.0113010036741605, -0.00194285569591290, 0.000225435774024964},
+	// precision 13
+	{-0.41655270946462997, -0.22146677040685156, 0.38862131236999947, 0.45340979746062371, -0.36264738324476375, 0.12304650053558529, -0.01701540384555510, 0.00102750367080838},
+	// precision 14
+	{-0.371009760230692, 0.00978811941207509, 0.185796293324165, 0.203015527328432, -0.116710521803686, 0.0431106699492820, -0.00599583540511831, 0.000449704299509437},
+	// precision 15
+	{-0.38215145543875273, -0.89069400536090837, 0.37602335774678869, 0.99335977440682377, -0.65577441638318956, 0.18332342129703610, -0.02241529633062872, 0.00121399789330194},
+	// precision 16
+	{-0.37331876643753059, -1.41704077448122989, 0.40729184796612533, 1.56152033906584164, -0.99242233534286128, 0.26064681399483092, -0.03053811369682807, 0.00155770210179105},
+	// precision 17
+	{-0.36775502299404605, 0.53831422351377967, 0.76970289278767923, 0.55002583586450560, -0.74575588261146941, 0.25711835785821952, -0.03437902606864149, 0.00185949146371616},
+	// precision 18
+	{-0.36479623325960542, 0.99730412328635032, 1.55354386230081221, 1.25932677198028919, -1.53325948209110163, 0.47801042200056593, -0.05951025172951174, 0.00291076804642205},
+}
@@ -0,0 +1,122 @@
+package hll
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+)
+
+// A log is stored as a record, a header of rECMARKER, version, algorithm,
+// precision, hash id and 8 bytes little endian seed, from version 2 followed
+// by the 8 bytes creation and modification unix times, then the sALTLEN bytes
+// salt for the siphash hash id and the serialized sketch. Records written
+// before the header was introduced are read too, either the bare sketch or
+// sALTMARKER, salt and the sketch. The times of the logs read from records
+// without them are 0
+const (
+	rECMARKER   byte = 0xfc
+	rECVERSION  byte = 2
+	rECHDRLEN        = 13
+	rECTIMESLEN      = 16
+	sALTMARKER  byte = 0xfd
+)
+
+// Hash functions of the items
+const (
+	hASHMURMUR32 byte = iota
+	hASHMURMUR64
+	hASHSIPHASH
+)
+
+// Returns the hash function and the seed the log hashes its items with
+func (hpl *hyperlog) hashid() (byte, uint64) {
+	if hpl.salt != nil {
+		return hASHSIPHASH, 0
+	}
+	switch hpl.algo {
+	case HLLPP:
+		return hASHMURMUR64, sEED
+	case REDIS:
+		return hASHMURMUR64, rEDISSEED
+	}
+	return hASHMURMUR32, sEED
+}
+
+// Serializes the log for the store. Exported sketches never carry the salt
+func (hpl *hyperlog) serializeRecord() []byte {
+	data := hpl.serialize()
+	hashid, seed := hpl.hashid()
+	ret := make([]byte, rECHDRLEN, rECHDRLEN+rECTIMESLEN+len(hpl.salt)+len(data))
+	ret[0] = rECMARKER
+	ret[1] = rECVERSION
+	ret[2] = hpl.algo
+	ret[3] = hpl.precision
+	ret[4] = hashid
+	binary.LittleEndian.PutUint64(ret[5:], seed)
+	ret = binary.LittleEndian.AppendUint64(ret, hpl.ctime)
+	ret = binary.LittleEndian.AppendUint64(ret, atomic.LoadUint64(&hpl.mtime))
+	ret = append(ret, hpl.salt...)
+	return append(ret, data...)
+}
+
+func deserializeRecord(key string, expiry uint64, data []byte) (bool, *hyperlog) {
+	if len(data) == 0 {
+		return false, nil
+	}
+	switch data[0] {
+	case rECMARKER:
+	case sALTMARKER:
+		if len(data) <= 1+sALTLEN {
+			return false, nil
+		}
+		ok, hpl := deserialize(key, expiry, data[1+sALTLEN:])
+		if !ok || hpl.algo == REDIS {
+			return false, nil
+		}
+		hpl.salt = append([]byte(nil), data[1:1+sALTLEN]...)
+		hpl.ctime, hpl.mtime = 0, 0
+		return true, hpl
+	default:
+		ok, hpl := deserialize(key, expiry, data)
+		if ok {
+			hpl.ctime, hpl.mtime = 0, 0
+		}
+		return ok, hpl
+	}
+	if len(data) <= rECHDRLEN || data[1] == 0 || data[1] > rECVERSION {
+		return false, nil
+	}
+	version, algo, precision, hashid := data[1], data[2], data[3], data[4]
+	seed := binary.LittleEndian.Uint64(data[5:])
+	if !validLogParams(precision, algo) {
+		return false, nil
+	}
+	data = data[rECHDRLEN:]
+	var ctime, mtime uint64
+	if version >= 2 {
+		if len(data) <= rECTIMESLEN {
+			return false, nil
+		}
+		ctime = binary.LittleEndian.Uint64(data)
+		mtime = binary.LittleEndian.Uint64(data[8:])
+		data = data[rECTIMESLEN:]
+	}
+	var salt []byte
+	if hashid == hASHSIPHASH {
+		if len(data) <= sALTLEN || algo == REDIS {
+			return false, nil
+		}
+		salt = append([]byte(nil), data[:sALTLEN]...)
+		data = data[sALTLEN:]
+	}
+	ok, hpl := deserialize(key, expiry, data)
+	if !ok || hpl.algo != algo || hpl.precision != precision {
+		return false, nil
+	}
+	hpl.salt = salt
+	hpl.ctime, hpl.mtime = ctime, mtime
+	// the log can only keep on counting if it hashes like it was stored
+	if expid, expseed := hpl.hashid(); expid != hashid || expseed != seed {
+		return false, nil
+	}
+	return true, hpl
+}
@@ -0,0 +1,182 @@
+package hll
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Redis stores a hyperloglog as a string with a 16 byte header, "HYLL", one
+// byte encoding, 3 unused bytes and 8 bytes cached cardinality, followed by
+// the registers in either dense or sparse encoding
+const (
+	rEDISHDRLEN    = 16
+	rEDISDENSE     = 0
+	rEDISSPARSE    = 1
+	rEDISREGBITS   = 6
+	rEDISDENSELEN  = rEDISHDRLEN + (1<<rEDISPREC)*rEDISREGBITS/8
+	rEDISSPARSEMAX = 3000
+	rEDISMAXVAL    = 64 - uint32(rEDISPREC) + 1
+	// sparse opcodes, ZERO 00xxxxxx, XZERO 01xxxxxx yyyyyyyy, VAL 1vvvvvxx
+	rEDISXZERO     = 0x40
+	rEDISVAL       = 0x80
+	rEDISVALMAX    = 32
+	rEDISVALRUNMAX = 4
+	rEDISZEROMAX   = 64
+	rEDISXZEROMAX  = 16384
+)
+
+var rEDISMAGIC = []byte("HYLL")
+
+// Returns the log as a Redis hyperloglog string. Sparse encoding is used if
+// it fits in the default hll-sparse-max-bytes of Redis, otherwise dense
+func (hpl *hyperlog) redisSerialize() []byte {
+	hpl.lock.RLock()
+	defer hpl.lock.RUnlock()
+	if ret := hpl.redisSparse(); ret != nil {
+		return ret
+	}
+	ret := make([]byte, rEDISDENSELEN)
+	redisHeader(ret, rEDISDENSE)
+	regs := ret[rEDISHDRLEN:]
+	hpl.foreachslot(func(idx uint32, val uint32) {
+		pos := idx * rEDISREGBITS
+		b := pos / 8
+		fb := pos & 7
+		regs[b] |= byte(val << fb)
+		if fb > 8-rEDISREGBITS {
+			regs[b+1] |= byte(val >> (8 - fb))
+		}
+	})
+	return ret
+}
+
+func redisHeader(data []byte, encoding byte) {
+	copy(data, rEDISMAGIC)
+	data[4] = encoding
+	// mark the cached cardinality invalid, redis recomputes it
+	data[15] = 0x80
+}
+
+// Returns nil if the log can't be sparse encoded within rEDISSPARSEMAX bytes
+func (hpl *hyperlog) redisSparse() []byte {
+	ret := make([]byte, rEDISHDRLEN, rEDISHDRLEN+64)
+	redisHeader(ret, rEDISSPARSE)
+	addzeros := func(zeros uint32) {
+		for zeros > 0 {
+			if zeros <= rEDISZEROMAX {
+				ret = append(ret, byte(zeros-1))
+				return
+			}
+			run := zeros
+			if run > rEDISXZEROMAX {
+				run = rEDISXZEROMAX
+			}
+			ret = append(ret, rEDISXZERO|byte((run-1)>>8), byte(run-1))
+			zeros -= run
+		}
+	}
+	next := uint32(0)
+	runval := uint32(0)
+	runlen := uint32(0)
+	flushrun := func() {
+		if runlen > 0 {
+			ret = append(ret, rEDISVAL|byte(runval-1)<<2|byte(runlen-1))
+		}
+		runlen = 0
+	}
+	fits := true
+	hpl.foreachslot(func(idx uint32, val uint32) {
+		if !fits || val > rEDISVALMAX {
+			fits = false
+			return
+		}
+		if idx != next || val != runval || runlen == rEDISVALRUNMAX {
+			flushrun()
+		}
+		if idx != next {
+			addzeros(idx - next)
+		}
+		runval = val
+		runlen++
+		next = idx + 1
+		if len(ret) > rEDISSPARSEMAX {
+			fits = false
+		}
+	})
+	flushrun()
+	addzeros(hpl.numslot - next)
+	if !fits || len(ret) > rEDISSPARSEMAX {
+		return nil
+	}
+	return ret
+}
+
+// Decodes a Redis hyperloglog string into a log using the REDIS algorithm
+func deserializeRedis(key string, data []byte) (bool, *hyperlog) {
+	if len(data) < rEDISHDRLEN || !bytes.Equal(data[:4], rEDISMAGIC) {
+		return false, nil
+	}
+	hpl := newHyperLog(key, 0, rEDISPREC, REDIS)
+	var entries []uint32
+	switch data[4] {
+	case rEDISDENSE:
+		if len(data) != rEDISDENSELEN {
+			return false, nil
+		}
+		regs := data[rEDISHDRLEN:]
+		for idx := uint32(0); idx < hpl.numslot; idx++ {
+			pos := idx * rEDISREGBITS
+			b := pos / 8
+			fb := pos & 7
+			val := uint32(regs[b]) >> fb
+			if fb > 8-rEDISREGBITS {
+				val |= uint32(regs[b+1]) << (8 - fb)
+			}
+			val &= 1<<rEDISREGBITS - 1
+			if val > rEDISMAXVAL {
+				return false, nil
+			}
+			if val > 0 {
+				entries = append(entries, idx<<8|val)
+			}
+		}
+	case rEDISSPARSE:
+		idx := uint32(0)
+		for pos := rEDISHDRLEN; pos < len(data); pos++ {
+			op := data[pos]
+			var run uint32
+			switch {
+			case op&rEDISVAL != 0:
+				run = uint32(op&0x3) + 1
+				val := uint32(op>>2&0x1f) + 1
+				if idx+run > hpl.numslot {
+					return false, nil
+				}
+				for i := uint32(0); i < run; i++ {
+					entries = append(entries, (idx+i)<<8|val)
+				}
+			case op&rEDISXZERO != 0:
+				if pos+1 >= len(data) {
+					return false, nil
+				}
+				pos++
+				run = uint32(binary.BigEndian.Uint16([]byte{op & 0x3f, data[pos]})) + 1
+			default:
+				run = uint32(op) + 1
+			}
+			idx += run
+			if idx > hpl.numslot {
+				return false, nil
+			}
+		}
+		if idx != hpl.numslot {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+	if !hpl.loadsparse(entries) {
+		return false, nil
+	}
+	return true, hpl
+}
@@ -0,0 +1,108 @@
+package hll
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var ErrSameLog = errors.New("Source and destination logs are the same")
+
+// Renames the log src to dst, keeping its expiry and times. An existing dst is
+// replaced unless overwrite is false, then ErrLogExists is returned. Returns
+// ErrLogNotExists if src doesn't exist
+func (hc *HllContainer) Rename(src string, dst string, overwrite bool) error {
+	if src == dst {
+		if !hc.Exists(src) {
+			return ErrLogNotExists
+		}
+		if !overwrite {
+			return ErrLogExists
+		}
+		return nil
+	}
+	return hc.copyLog(src, dst, overwrite, true)
+}
+
+// Copies the log src to dst with the expiry of src. An existing dst is
+// replaced if overwrite is true, otherwise ErrLogExists is returned. Returns
+// ErrLogNotExists if src doesn't exist
+func (hc *HllContainer) Copy(src string, dst string, overwrite bool) error {
+	if src == dst {
+		return ErrSameLog
+	}
+	return hc.copyLog(src, dst, overwrite, false)
+}
+
+// The maps of src and dst are locked together, so that no one sees both or
+// neither of the logs of a rename. In the write ahead log the new dst comes
+// before the delete of src, a crash in between leaves both logs. In the store
+// the delete of a replaced dst comes before the new dst, both are queued for
+// the map of dst. The updates of src which missed its copy are made again on
+// the src created afterwards
+func (hc *HllContainer) copyLog(src string, dst string, overwrite bool, remove bool) error {
+	srcslot := murmur3_32([]byte(src), sEED) & hc.hslot
+	dstslot := murmur3_32([]byte(dst), sEED) & hc.hslot
+	srchm, dsthm := hc.hllmaps[srcslot], hc.hllmaps[dstslot]
+	unlock := lockMaps(srchm, dsthm)
+	srclog := srchm.liveLog(src)
+	if srclog == nil {
+		unlock()
+		return ErrLogNotExists
+	}
+	oldlog := dsthm.liveLog(dst)
+	if oldlog != nil && !overwrite {
+		unlock()
+		return ErrLogExists
+	}
+	// the records of the changes made to the logs come before the records
+	// of the rename
+	if remove {
+		srclog.wallock.Lock()
+	}
+	dstlog := srclog.clone(dst, remove)
+	if remove {
+		dstlog.ctime, dstlog.mtime = srclog.ctime, atomic.LoadUint64(&srclog.mtime)
+	}
+	if oldlog != nil {
+		oldlog.wallock.Lock()
+		dsthm.removeLog(oldlog, nil)
+		oldlog.wallock.Unlock()
+	}
+	dsthm.logm[dst] = dstlog
+	dsthm.setLogExpiry(dstlog, srclog.expiry)
+	var seq uint64
+	if hc.wal != nil {
+		seq = hc.wal.Append(walSlots(wALREPLACE, dstlog, dstlog.slotentries()))
+	}
+	if hc.store != nil {
+		atomic.AddInt32(&dstlog.updated, 1)
+		hc.enqueueStoreUpd(dstslot, dstlog)
+	}
+	if remove {
+		seq = srchm.removeLog(srclog, walDelete(src))
+		srclog.wallock.Unlock()
+	}
+	unlock()
+	if hc.wal != nil {
+		return hc.walWait(seq)
+	}
+	return nil
+}
+
+// Write locks the maps in the order of their slots, returns the function to
+// unlock them
+func lockMaps(a *hllMap, b *hllMap) func() {
+	if a == b {
+		a.mutex.Lock()
+		return a.mutex.Unlock
+	}
+	if a.slot > b.slot {
+		a, b = b, a
+	}
+	a.mutex.Lock()
+	b.mutex.Lock()
+	return func() {
+		b.mutex.Unlock()
+		a.mutex.Unlock()
+	}
+}
@@ -0,0 +1,180 @@
+package hll
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// A scan walks the maps in order and returns all the keys of a map in one
+// call, so the cursor is the index of the map to continue from, "0" starts and
+// ends a scan
+const (
+	sCANDEFCOUNT = 10
+	sCANMAXCOUNT = 1000
+	// keys examined per requested key before a scan returns early
+	sCANWORK = 10
+)
+
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+type ScannedKey struct {
+	Key string
+	// remaining time to live in seconds, -1 if the log has no expiry
+	TTL int64
+}
+
+// Returns about count keys matching the glob pattern and the cursor to continue
+// the scan from, "0" when the scan is complete. An empty pattern matches all
+// the keys. The keys existing during the whole scan are returned exactly once,
+// the keys added or deleted meanwhile may or may not be. The keys of a map are
+// returned together, so a call may return more keys than count, or fewer, even
+// none, before the scan is complete
+func (hc *HllContainer) Scan(cursor string, pattern string, count int) ([]ScannedKey, string, error) {
+	part, err := strconv.ParseUint(cursor, 10, 32)
+	if err != nil || part >= uint64(len(hc.hllmaps)) {
+		return nil, "", ErrInvalidCursor
+	}
+	if count <= 0 {
+		count = sCANDEFCOUNT
+	} else if count > sCANMAXCOUNT {
+		count = sCANMAXCOUNT
+	}
+	prefix := globPrefix(pattern)
+	now := nowMillis()
+	var keys []ScannedKey
+	examined := 0
+	for i := int(part); i < len(hc.hllmaps); i++ {
+		hm := hc.hllmaps[i]
+		hm.mutex.RLock()
+		examined += len(hm.logm)
+		for key, hlog := range hm.logm {
+			if hlog.expired(now) || !strings.HasPrefix(key, prefix) ||
+				(pattern != "" && !globMatch(pattern, key)) {
+				continue
+			}
+			keys = append(keys, ScannedKey{Key: key, TTL: remainingTTL(hlog.expiry, now)})
+		}
+		hm.mutex.RUnlock()
+		if (len(keys) >= count || examined >= count*sCANWORK) && i+1 < len(hc.hllmaps) {
+			return keys, strconv.Itoa(i + 1), nil
+		}
+	}
+	return keys, "0", nil
+}
+
+// Returns the time to live in seconds, rounded up, of the expiry after the
+// unix time now, both in milliseconds
+func remainingTTL(expiry uint64, now uint64) int64 {
+	ttl := remainingPTTL(expiry, now)
+	if ttl <= 0 {
+		return ttl
+	}
+	return (ttl + 999) / 1000
+}
+
+// Like remainingTTL in milliseconds
+func remainingPTTL(expiry uint64, now uint64) int64 {
+	if expiry == 0 {
+		return -1
+	}
+	if expiry <= now {
+		return 0
+	}
+	return int64(expiry - now)
+}
+
+// Returns the literal prefix of the glob pattern, all the matching keys start
+// with it
+func globPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?[\\"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+// Matches the key against the glob pattern, like the MATCH of the Redis SCAN.
+// * matches any bytes, ? matches one byte, [abc], [a-z] and [^abc] match a
+// byte of or not of the class and \ escapes the next byte. A malformed class
+// never matches
+func globMatch(pattern string, key string) bool {
+	px, kx := 0, 0
+	// where to resume after a mismatch, the last * matches one byte more
+	starpx, starkx := -1, 0
+	for kx < len(key) {
+		if px < len(pattern) {
+			switch c := pattern[px]; c {
+			case '*':
+				starpx, starkx = px, kx
+				px++
+				continue
+			case '?':
+				px++
+				kx++
+				continue
+			case '[':
+				if n, ok := matchClass(pattern[px:], key[kx]); ok {
+					px += n
+					kx++
+					continue
+				}
+			case '\\':
+				if px+1 < len(pattern) {
+					c = pattern[px+1]
+					px++
+				}
+				fallthrough
+			default:
+				if c == key[kx] {
+					px++
+					kx++
+					continue
+				}
+			}
+		}
+		if starpx < 0 {
+			return false
+		}
+		starkx++
+		px, kx = starpx+1, starkx
+	}
+	for px < len(pattern) && pattern[px] == '*' {
+		px++
+	}
+	return px == len(pattern)
+}
+
+// Matches the byte against the class at the start of the pattern, returns the
+// length of the class
+func matchClass(pattern string, c byte) (int, bool) {
+	i := 1
+	negate := i < len(pattern) && (pattern[i] == '^' || pattern[i] == '!')
+	if negate {
+		i++
+	}
+	matched := false
+	for first := true; i < len(pattern); first = false {
+		lo := pattern[i]
+		if lo == ']' && !first {
+			return i + 1, matched != negate
+		}
+		if lo == '\\' && i+1 < len(pattern) {
+			i++
+			lo = pattern[i]
+		}
+		hi := lo
+		if i+2 < len(pattern) && pattern[i+1] == '-' && pattern[i+2] != ']' {
+			hi = pattern[i+2]
+			i += 2
+			if hi == '\\' && i+1 < len(pattern) {
+				i++
+				hi = pattern[i]
+			}
+		}
+		if lo <= c && c <= hi {
+			matched = true
+		}
+		i++
+	}
+	return 0, false
+}
@@ -1,5 +1,10 @@
 package hll
 
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
 func murmur3_64(data []byte, seed uint64) uint64 {
 	var m uint64 = 0xc6a4a7935bd1e995
 	var r uint64 = 47
@@ -97,3 +102,53 @@ func murmur3_32(data []byte, seed uint32) uint32 {
 	hashval = hashval ^ (hashval >> 16)
 	return hashval
 }
+
+func sipround(v0, v1, v2, v3 uint64) (uint64, uint64, uint64, uint64) {
+	v0 += v1
+	v1 = bits.RotateLeft64(v1, 13)
+	v1 ^= v0
+	v0 = bits.RotateLeft64(v0, 32)
+	v2 += v3
+	v3 = bits.RotateLeft64(v3, 16)
+	v3 ^= v2
+	v0 += v3
+	v3 = bits.RotateLeft64(v3, 21)
+	v3 ^= v0
+	v2 += v1
+	v1 = bits.RotateLeft64(v1, 17)
+	v1 ^= v2
+	v2 = bits.RotateLeft64(v2, 32)
+	return v0, v1, v2, v3
+}
+
+// SipHash-2-4 of data with the 16 byte key
+func siphash(key []byte, data []byte) uint64 {
+	k0 := binary.LittleEndian.Uint64(key)
+	k1 := binary.LittleEndian.Uint64(key[8:])
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+	length := len(data)
+	numeightbytes := length - (length & 7)
+	for i := 0; i < numeightbytes; i += 8 {
+		m := binary.LittleEndian.Uint64(data[i:])
+		v3 ^= m
+		v0, v1, v2, v3 = sipround(v0, v1, v2, v3)
+		v0, v1, v2, v3 = sipround(v0, v1, v2, v3)
+		v0 ^= m
+	}
+	last := uint64(length) << 56
+	for i, b := range data[numeightbytes:] {
+		last |= uint64(b) << (8 * uint(i))
+	}
+	v3 ^= last
+	v0, v1, v2, v3 = sipround(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipround(v0, v1, v2, v3)
+	v0 ^= last
+	v2 ^= 0xff
+	for i := 0; i < 4; i++ {
+		v0, v1, v2, v3 = sipround(v0, v1, v2, v3)
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,352 @@
+package hll
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/nipuntalukdar/hllserver/hllogs"
+	"github.com/nipuntalukdar/hllserver/hllstore"
+	"sync/atomic"
+	"time"
+)
+
+// A change of a log is recorded in the write ahead log as the op, 4 bytes
+// little endian key length and the key, followed by the arguments of the op.
+// wALSLOTS and wALREPLACE carry the algorithm, precision, salt length, salt,
+// 8 bytes expiry and the slots as 4 bytes index<<8 | value entries. wALSLOTS
+// raises the slots to the entry values and creates the log if it doesn't
+// exist, so replaying it again is harmless. wALEXPIRY carries the 8 bytes
+// expiry, 0 if the log has no expiry. The expiry is in milliseconds if the op
+// has the wALMILLIS bit, older records have it in seconds. With the wALTIMES
+// bit, wALSLOTS and wALREPLACE carry the 8 bytes creation and modification
+// times of the log after the expiry
+const (
+	wALSLOTS byte = iota + 1
+	wALREPLACE
+	wALDELETE
+	wALEXPIRY
+)
+
+const (
+	wALMILLIS byte = 0x80
+	wALTIMES  byte = 0x40
+)
+
+const (
+	wALKEYOFF    = 5
+	cKPTINTERVAL = 60 * time.Second
+)
+
+type walOp struct {
+	op        byte
+	key       string
+	algo      uint8
+	precision uint8
+	salt      []byte
+	expiry    uint64
+	// 0 in the records without the times
+	ctime   uint64
+	mtime   uint64
+	entries []uint32
+}
+
+func walRecord(op byte, key string, size int) []byte {
+	ret := make([]byte, wALKEYOFF, wALKEYOFF+len(key)+size)
+	ret[0] = op | wALMILLIS
+	binary.LittleEndian.PutUint32(ret[1:], uint32(len(key)))
+	return append(ret, key...)
+}
+
+func walSlots(op byte, hlog *hyperlog, entries []uint32) []byte {
+	ret := walRecord(op, hlog.key, 27+len(hlog.salt)+4*len(entries))
+	ret[0] |= wALTIMES
+	ret = append(ret, hlog.algo, hlog.precision, byte(len(hlog.salt)))
+	ret = append(ret, hlog.salt...)
+	ret = binary.LittleEndian.AppendUint64(ret, hlog.expiry)
+	ret = binary.LittleEndian.AppendUint64(ret, hlog.ctime)
+	ret = binary.LittleEndian.AppendUint64(ret, atomic.LoadUint64(&hlog.mtime))
+	for _, entry := range entries {
+		ret = binary.LittleEndian.AppendUint32(ret, entry)
+	}
+	return ret
+}
+
+func walDelete(key string) []byte {
+	return walRecord(wALDELETE, key, 0)
+}
+
+func walExpiry(key string, expiry uint64) []byte {
+	return binary.LittleEndian.AppendUint64(walRecord(wALEXPIRY, key, 8), expiry)
+}
+
+func decodeWalOp(data []byte) (*walOp, bool) {
+	if len(data) < wALKEYOFF {
+		return nil, false
+	}
+	keylen := binary.LittleEndian.Uint32(data[1:])
+	if uint64(len(data)-wALKEYOFF) < uint64(keylen) {
+		return nil, false
+	}
+	wop := &walOp{op: data[0] &^ (wALMILLIS | wALTIMES),
+		key: string(data[wALKEYOFF : wALKEYOFF+keylen])}
+	millis := data[0]&wALMILLIS != 0
+	times := data[0]&wALTIMES != 0
+	data = data[wALKEYOFF+keylen:]
+	switch wop.op {
+	case wALSLOTS, wALREPLACE:
+		if len(data) < 3 {
+			return nil, false
+		}
+		wop.algo, wop.precision = data[0], data[1]
+		saltlen := int(data[2])
+		data = data[3:]
+		fixed := saltlen + 8
+		if times {
+			fixed += 16
+		}
+		if !validLogParams(wop.precision, wop.algo) || wop.precision == 0 ||
+			(saltlen != 0 && saltlen != sALTLEN) || len(data) < fixed ||
+			(len(data)-fixed)%4 != 0 {
+			return nil, false
+		}
+		if saltlen > 0 {
+			wop.salt = append([]byte(nil), data[:saltlen]...)
+		}
+		wop.expiry = binary.LittleEndian.Uint64(data[saltlen:])
+		if times {
+			wop.ctime = binary.LittleEndian.Uint64(data[saltlen+8:])
+			wop.mtime = binary.LittleEndian.Uint64(data[saltlen+16:])
+		}
+		data = data[fixed:]
+		numslot := uint32(1) << wop.precision
+		for len(data) > 0 {
+			entry := binary.LittleEndian.Uint32(data)
+			if entry>>8 >= numslot || entry&0xff == 0 {
+				return nil, false
+			}
+			wop.entries = append(wop.entries, entry)
+			data = data[4:]
+		}
+	case wALDELETE:
+		if len(data) != 0 {
+			return nil, false
+		}
+	case wALEXPIRY:
+		if len(data) != 8 {
+			return nil, false
+		}
+		wop.expiry = binary.LittleEndian.Uint64(data)
+	default:
+		return nil, false
+	}
+	if !millis {
+		wop.expiry = secondsToMillis(wop.expiry)
+	}
+	return wop, true
+}
+
+// Appends the change to the write ahead log, in ack mode returns only after the
+// change is durable, returns ErrNotDurable if it can't be made durable
+func (hc *HllContainer) walLog(record []byte) error {
+	return hc.walWait(hc.wal.Append(record))
+}
+
+// In ack mode waits for the changes up to seq to be durable
+func (hc *HllContainer) walWait(seq uint64) error {
+	if !hc.walack {
+		return nil
+	}
+	if err := hc.wal.Wait(seq); err != nil {
+		hllogs.Log.Errorf("Change not durable in write ahead log: %s", err)
+		return ErrNotDurable
+	}
+	return nil
+}
+
+// Applies the changes in the write ahead log to the logs in the store, then
+// truncates the write ahead log. Must be called during startup before restore
+func (hc *HllContainer) replayWal() error {
+	logs := make(map[string]*hyperlog)
+	// keys with a corrupt record in the store, restore quarantines them
+	corrupt := make(map[string]bool)
+	load := func(key string) *hyperlog {
+		if hlog, ok := logs[key]; ok {
+			return hlog
+		}
+		var hlog *hyperlog
+		data, expiry, err := hc.store.Get(key)
+		if err == nil {
+			var ok bool
+			if ok, hlog = deserializeRecord(key, expiry, data); !ok {
+				err = ErrInvalidSketch
+			}
+		}
+		if err != nil && err != hllstore.ErrKeyNotExists {
+			hllogs.Log.Errorf("Not replaying changes of key %s: %s", key, err)
+			corrupt[key] = true
+		}
+		logs[key] = hlog
+		return hlog
+	}
+	numops := 0
+	last, err := hc.wal.Replay(func(record []byte) error {
+		wop, ok := decodeWalOp(record)
+		if !ok {
+			hllogs.Log.Error("Skipping invalid write ahead log record")
+			return nil
+		}
+		numops++
+		if wop.op == wALDELETE {
+			logs[wop.key] = nil
+			delete(corrupt, wop.key)
+			return nil
+		}
+		hlog := load(wop.key)
+		if corrupt[wop.key] {
+			return nil
+		}
+		switch wop.op {
+		case wALSLOTS, wALREPLACE:
+			if hlog == nil || wop.op == wALREPLACE || hlog.algo != wop.algo ||
+				hlog.precision != wop.precision || !bytes.Equal(hlog.salt, wop.salt) {
+				replaced := hlog
+				hlog = newHyperLog(wop.key, 0, wop.precision, wop.algo)
+				hlog.salt = wop.salt
+				if replaced != nil {
+					hlog.ctime = replaced.ctime
+				}
+				logs[wop.key] = hlog
+			}
+			hlog.expiry = wop.expiry
+			hlog.mergeentries(wop.entries)
+			// the log keeps the times of the change, not of the replay
+			if wop.ctime != 0 {
+				hlog.ctime, hlog.mtime = wop.ctime, wop.mtime
+			}
+		case wALEXPIRY:
+			if hlog != nil {
+				hlog.expiry = wop.expiry
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for key, hlog := range logs {
+		if corrupt[key] {
+			continue
+		}
+		write := func() error {
+			if hlog == nil {
+				return hc.store.Delete(key)
+			}
+			return hc.store.Update(key, hlog.expiry, hlog.serializeRecord())
+		}
+		err = write()
+		if err == hllstore.ErrOverloaded {
+			if err = hc.store.Flush(); err == nil {
+				err = write()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err = hc.store.Flush(); err != nil {
+		return err
+	}
+	hllogs.Log.Infof("Replayed %d changes of %d keys from write ahead log", numops, len(logs))
+	return hc.wal.Truncate(last)
+}
+
+// Writes the changed logs to the store and truncates the write ahead log
+// every interval
+func (hc *HllContainer) checkpoints(interval time.Duration) {
+	if interval <= 0 {
+		interval = cKPTINTERVAL
+	}
+	defer hc.workers.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			hc.checkpoint()
+		case <-hc.shutdown:
+			return
+		}
+	}
+}
+
+func (hc *HllContainer) checkpoint() {
+	// the changes in the sealed segments are all applied to the logs
+	sealed, err := hc.wal.Rotate()
+	if err != nil {
+		hllogs.Log.Errorf("Checkpoint failed: %s", err)
+		return
+	}
+	done := make(chan error, 1)
+	select {
+	case hc.ckptchan <- done:
+		err = <-done
+	case <-hc.shutdown:
+		return
+	}
+	if err != nil {
+		// the sealed segments are kept until a checkpoint succeeds
+		hllogs.Log.Errorf("Checkpoint failed: %s", err)
+		return
+	}
+	if err = hc.wal.Truncate(sealed); err != nil {
+		hllogs.Log.Errorf("Truncating write ahead log failed: %s", err)
+	}
+}
+
+// Writes the logs with changes not yet sent to the store and flushes the
+// store. Must be called from the store updates goroutine
+func (hc *HllContainer) flushLogs() error {
+	var ret error
+	write := func(hlog *hyperlog) {
+		err := hc.updateStore(hlog)
+		if err == hllstore.ErrOverloaded {
+			// let the store catch up and retry
+			if err = hc.flushStore(); err == nil {
+				err = hc.updateStore(hlog)
+			}
+		}
+		if err != nil && ret == nil {
+			ret = err
+		}
+	}
+	// the deleted logs aren't in the maps, their queued deletes are written
+	// too so that truncating the write ahead log can't bring them back from
+	// the store
+	for _, hlog := range hc.takeQueuedDeletes() {
+		write(hlog)
+	}
+	for n := len(hc.updchan); n > 0; n-- {
+		select {
+		case hlog := <-hc.updchan:
+			hc.pending.Add(-1)
+			write(hlog)
+		default:
+		}
+	}
+	for _, hm := range hc.hllmaps {
+		var changed []*hyperlog
+		hm.mutex.RLock()
+		for _, hlog := range hm.logm {
+			if hlog.getUpdCount() > 0 && atomic.LoadUint32(&hlog.deleted) == 0 {
+				changed = append(changed, hlog)
+			}
+		}
+		hm.mutex.RUnlock()
+		for _, hlog := range changed {
+			write(hlog)
+		}
+	}
+	if err := hc.flushStore(); err != nil && ret == nil {
+		ret = err
+	}
+	return ret
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/nipuntalukdar/hllserver/handlers/httphandler"
+	"github.com/nipuntalukdar/hllserver/handlers/resphandler"
 	"github.com/nipuntalukdar/hllserver/handlers/thrift"
 	"github.com/nipuntalukdar/hllserver/hll"
 	"github.com/nipuntalukdar/hllserver/hllogs"
@@ -18,9 +20,13 @@ import (
 )
 
 func main() {
+	if runSubcommand(os.Args[1:]) {
+		return
+	}
 
 	http_addr := flag.String("http", ":55123", "give http lister_address")
 	thrift_port := flag.String("thrift", "127.0.0.1:55124", "thrift rpc address")
+	resp_addr := flag.String("resp", "127.0.0.1:55125", "redis protocol listener address")
 	persistence := flag.Bool("persist", false, "should persist the hyperlogs in db?")
 	persistdbdir := flag.String("db", "/tmp", "directory for hyperlog db")
 	persitdbname := flag.String("dbfile", "hyperlogs.db", "hyperlogdb file")
@@ -28,18 +34,54 @@ func main() {
 	logbackup := flag.Int("logbackup", 10, "maximum backup for logs")
 	logsize := flag.Int("logfilesize", 2048000, "log rollover size")
 	loglevel := flag.String("loglevel", "INFO", "logging level")
+	saltfile := flag.String("saltfile", "", "file with the secret salts of the key namespaces")
+	estimator := flag.String("estimator", "default",
+		"cardinality estimator, one of default, loglogbeta, ertl and mle")
+	waldir := flag.String("wal", "", "write ahead log directory, needs -persist")
+	walsync := flag.Duration("walsync", 10*time.Millisecond,
+		"interval to fsync the write ahead log")
+	walack := flag.Bool("walack", false,
+		"return updates only after they are durable in the write ahead log")
+	walcheckpoint := flag.Duration("walcheckpoint", 60*time.Second,
+		"interval to checkpoint the hyperlogs to db and truncate the write ahead log")
+	shutdowntimeout := flag.Duration("shutdowntimeout", 30*time.Second,
+		"maximum time to write the pending updates to db on shutdown")
+	storequeue := flag.Int("storequeue", 10240, "maximum updates queued for the db writer")
+	maxpending := flag.Int("maxpending", 0,
+		"maximum hyperlogs waiting to be written to db before updates are rejected, 0 for no limit")
 	flag.Parse()
 
 	logmod := hllogs.InitLogger(*logbackup, *logsize, *logfile, *loglevel)
 	logger := hllogs.GetLogger()
 	logger.Info("Initialized logs")
 
+	if !hll.SetDefaultEstimator(*estimator) {
+		logger.Fatalf("Unknown estimator %s", *estimator)
+	}
+
 	var store hllstore.HllStore
 	if *persistence {
-		store = hllstore.NewBoltStore(*persistdbdir, *persitdbname)
+		store = hllstore.NewBoltStoreWithQueue(*persistdbdir, *persitdbname, *storequeue)
+	}
+	var wal *hllstore.Wal
+	if *waldir != "" {
+		if store == nil {
+			logger.Fatal("Write ahead log needs -persist")
+		}
+		var err error
+		wal, err = hllstore.OpenWal(*waldir, *walsync)
+		if err != nil {
+			logger.Fatalf("Couldn't open the write ahead log: %v", err)
+		}
 	}
 
-	hlc := hll.NewHllContainer(1024, store)
+	hlc := hll.NewHllContainerWithWal(1024, store, wal, *walack, *walcheckpoint)
+	hlc.SetMaxPendingUpdates(*maxpending)
+	if *saltfile != "" {
+		if err := loadSalts(hlc, *saltfile); err != nil {
+			logger.Fatalf("Couldn't load the salts: %v", err)
+		}
+	}
 	thandler, err := thandler.NewThriftHandler(hlc)
 	if err != nil {
 		logger.Fatal("Could not initialize the thrift handler")
@@ -51,57 +93,109 @@ func main() {
 		logger.Fatal("Couldn't create server socket for")
 	}
 
+	respserver, err := resphandler.NewRespServer(hlc, *resp_addr)
+	if err != nil {
+		logger.Fatal("Couldn't create the redis protocol listener")
+	}
+
 	// Start the servers
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
 
+	tserver := thrift.NewTSimpleServer4(hllprocessor, ssock,
+		thrift.NewTBufferedTransportFactory(2048000), thrift.NewTBinaryProtocolFactoryDefault())
 	go func() {
 		defer wg.Done()
-		server := thrift.NewTSimpleServer4(hllprocessor, ssock,
-			thrift.NewTBufferedTransportFactory(2048000), thrift.NewTBinaryProtocolFactoryDefault())
 		logger.Info("Starting the thrift server")
-		server.Serve()
+		tserver.Serve()
 	}()
 
+	server := &http.Server{
+		Addr:         *http_addr,
+		ReadTimeout:  180 * time.Second,
+		WriteTimeout: 180 * time.Second,
+	}
 	go func() {
 		defer wg.Done()
-		server := &http.Server{
-			Addr:         *http_addr,
-			ReadTimeout:  180 * time.Second,
-			WriteTimeout: 180 * time.Second,
-		}
 		haddlogh := httphandler.NewHttpAddLogHandler(hlc)
 		hdellogh := httphandler.NewHttpDelLogHandler(hlc)
 		updllogh := httphandler.NewHttpUpdateLogHandler(hlc)
+		updhashh := httphandler.NewHttpUpdateHashesHandler(hlc)
 		cardinalh := httphandler.NewHttpGetCardinalityHandler(hlc)
 		updexpiryh := httphandler.NewHttpUpdateExpiryHandler(hlc)
+		expireath := httphandler.NewHttpExpireAtHandler(hlc)
+		ttlh := httphandler.NewHttpTTLHandler(hlc)
+		persisth := httphandler.NewHttpPersistHandler(hlc)
+		mergelogh := httphandler.NewHttpMergeLogHandler(hlc)
+		intersecth := httphandler.NewHttpIntersectionHandler(hlc)
+		differenceh := httphandler.NewHttpDifferenceHandler(hlc)
+		sketchh := httphandler.NewHttpSketchHandler(hlc)
+		redislogh := httphandler.NewHttpRedisSketchHandler(hlc)
+		quarantineh := httphandler.NewHttpQuarantineHandler(hlc)
+		keysh := httphandler.NewHttpKeysHandler(hlc)
+		infoh := httphandler.NewHttpInfoHandler(hlc)
+		renameh := httphandler.NewHttpRenameHandler(hlc)
+		copyh := httphandler.NewHttpCopyHandler(hlc)
 		http.Handle("/addlogkey", haddlogh)
 		http.Handle("/dellogkey", hdellogh)
 		http.Handle("/updatelog", updllogh)
+		http.Handle("/updatehashes", updhashh)
 		http.Handle("/cardinality", cardinalh)
 		http.Handle("/updexpiry", updexpiryh)
+		http.Handle("/expireat", expireath)
+		http.Handle("/ttl", ttlh)
+		http.Handle("/persist", persisth)
+		http.Handle("/mergelog", mergelogh)
+		http.Handle("/intersection", intersecth)
+		http.Handle("/difference", differenceh)
+		http.Handle("/sketch", sketchh)
+		http.Handle("/redislog", redislogh)
+		http.Handle("/quarantine", quarantineh)
+		http.Handle("/keys", keysh)
+		http.Handle("/info", infoh)
+		http.Handle("/rename", renameh)
+		http.Handle("/copy", copyh)
 
 		logger.Info("Http listener starting")
 		server.ListenAndServe()
 	}()
 
+	go func() {
+		defer wg.Done()
+		logger.Info("Redis protocol listener starting")
+		respserver.Serve()
+	}()
+
 	// signal handlers
 	sigchan := make(chan os.Signal, 10)
 	signal.Notify(sigchan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGSTOP)
+	stopped := make(chan bool)
 	go func() {
 		s := <-sigchan
 		logger.Infof("Terminating hllserverd as signal:%v received", s)
-		hlc.Shutdown()
+		// no update must come in while the pending updates are drained
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdowntimeout)
+		server.Shutdown(ctx)
+		cancel()
+		tserver.Stop()
+		respserver.Stop()
+		if dropped := hlc.ShutdownTimeout(*shutdowntimeout); dropped > 0 {
+			logger.Errorf("Shutdown timed out, dropped %d pending updates", dropped)
+		}
+		if wal != nil {
+			wal.Close()
+		}
 		if store != nil {
 			store.FlushAndStop()
 		}
+		logger.Info("hllserverd stopped")
 		logmod.Shutdown()
-		time.Sleep(2 * time.Second)
-		os.Exit(0)
+		time.Sleep(100 * time.Millisecond)
+		close(stopped)
 	}()
 	// Wait for http/thrift servers to stop
 	wg.Wait()
 	sigchan <- syscall.SIGTERM
-	time.Sleep(2 * time.Second)
+	<-stopped
 	os.Exit(0)
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+)
+
+// Subcommands to move hyperloglogs between Redis and a running hllserverd
+// through the /redislog endpoint. The Redis hyperloglog is the raw string
+// value of the Redis key, as returned by GET
+var subcommands = map[string]func(args []string) error{
+	"redis-import": redisImport,
+	"redis-export": redisExport,
+}
+
+// Runs the subcommand if the first argument is one, returns false otherwise
+func runSubcommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	cmd, ok := subcommands[args[0]]
+	if !ok {
+		return false
+	}
+	if err := cmd(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+	return true
+}
+
+func redisLogURL(server string, logkey string, expiry uint64) string {
+	params := url.Values{}
+	params.Set("logkey", logkey)
+	if expiry > 0 {
+		params.Set("expiry", strconv.FormatUint(expiry, 10))
+	}
+	return server + "/redislog?" + params.Encode()
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s", resp.Status, body)
+	}
+	return nil
+}
+
+func redisImport(args []string) error {
+	flags := flag.NewFlagSet("redis-import", flag.ExitOnError)
+	server := flags.String("server", "http://127.0.0.1:55123", "hllserverd http address")
+	logkey := flags.String("logkey", "", "log key to import into")
+	file := flags.String("file", "-", "file with the Redis hyperloglog, - for stdin")
+	merge := flags.Bool("merge", false, "merge into the log key instead of replacing it")
+	expiry := flags.Uint64("expiry", 0, "expiry in seconds if the log key is created")
+	flags.Parse(args)
+	if *logkey == "" {
+		return fmt.Errorf("logkey is missing")
+	}
+	var data []byte
+	var err error
+	if *file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return err
+	}
+	method := http.MethodPut
+	if *merge {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, redisLogURL(*server, *logkey, *expiry),
+		bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-type", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func redisExport(args []string) error {
+	flags := flag.NewFlagSet("redis-export", flag.ExitOnError)
+	server := flags.String("server", "http://127.0.0.1:55123", "hllserverd http address")
+	logkey := flags.String("logkey", "", "log key to export")
+	file := flags.String("file", "-", "file to write the Redis hyperloglog, - for stdout")
+	flags.Parse(args)
+	if *logkey == "" {
+		return fmt.Errorf("logkey is missing")
+	}
+	resp, err := http.Get(redisLogURL(*server, *logkey, 0))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if *file == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*file, data, 0644)
+}
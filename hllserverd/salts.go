@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/nipuntalukdar/hllserver/hll"
+	"os"
+	"strings"
+)
+
+// Loads the namespace salts from the file, each line has a namespace and its
+// salt as 32 hex digits separated by whitespace. Empty lines and lines
+// starting with # are skipped
+func loadSalts(hlc *hll.HllContainer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("%s:%d: expected namespace and salt", path, lineno)
+		}
+		salt, err := hex.DecodeString(fields[1])
+		if err != nil {
+			return fmt.Errorf("%s:%d: invalid salt", path, lineno)
+		}
+		if err = hlc.SetNamespaceSalt(fields[0], salt); err != nil {
+			return fmt.Errorf("%s:%d: %v", path, lineno, err)
+		}
+	}
+	return scanner.Err()
+}
@@ -1,7 +1,6 @@
 package hllstore
 
 import (
-	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -13,9 +12,31 @@ import (
 )
 
 const (
-	bKTPREFIX = "bkt"
+	bKTPREFIX     = "bkt"
+	qUARANTINEBKT = "quarantine"
+	// the top byte of the stored expiry is the version of the value, values
+	// of version 0 are the expiry and the data, values of version 1 have a
+	// crc32c of the expiry and the data after the expiry. Versions 0 and 1
+	// have the expiry in seconds, version 2 in milliseconds
+	vALVERSION        = 2
+	vALHDRLEN         = 12
+	eXPIRYMASK uint64 = 1<<56 - 1
+	// default limit of the queued updates and deletes
+	wORKQUEUELEN = 10240
 )
 
+// The largest expiry the store keeps, in unix milliseconds
+const MaxExpiry = eXPIRYMASK
+
+var ErrInvalidValue = errors.New("Invalid data")
+var ErrChecksum = errors.New("Checksum mismatch")
+var ErrValueVersion = errors.New("Unknown value version")
+var ErrKeyNotExists = errors.New("Key not exists")
+var ErrInvalidKey = errors.New("Key is empty or too long")
+var ErrOverloaded = errors.New("Store overloaded")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
 const (
 	UPD uint16 = iota
 	DEL
@@ -33,12 +54,23 @@ type BoltStore struct {
 	dbname  string
 	db      *bolt.DB
 	bucketn []string
-	buckets []*bolt.Bucket
 	works   chan *mutation
-	flush   chan *sync.WaitGroup
+	flush   chan chan error
+	stop    chan chan error
+	errlock *sync.RWMutex
+	err     error
 }
 
 func NewBoltStore(dbdir string, dbname string) *BoltStore {
+	return NewBoltStoreWithQueue(dbdir, dbname, wORKQUEUELEN)
+}
+
+// Updates and deletes are queued for the writer of the db, at most queuelen of
+// them. When the queue is full they fail with ErrOverloaded
+func NewBoltStoreWithQueue(dbdir string, dbname string, queuelen int) *BoltStore {
+	if queuelen <= 0 {
+		queuelen = wORKQUEUELEN
+	}
 	dbpath := fmt.Sprintf("%s/%s", dbdir, dbname)
 	db, err := bolt.Open(dbpath, 0644, &bolt.Options{Timeout: 10 * time.Second})
 	if err != nil {
@@ -50,7 +82,6 @@ func NewBoltStore(dbdir string, dbname string) *BoltStore {
 		bucketn[i] = fmt.Sprintf("%s_%d", bKTPREFIX, i)
 		i++
 	}
-	buckets := make([]*bolt.Bucket, 8)
 	t, err := db.Begin(true)
 	if err != nil {
 		hllogs.Log.Fatalf("Fatal error %s", err)
@@ -63,71 +94,183 @@ func NewBoltStore(dbdir string, dbname string) *BoltStore {
 		}
 		i++
 	}
+	if _, err = t.CreateBucketIfNotExists([]byte(qUARANTINEBKT)); err != nil {
+		hllogs.Log.Fatalf("Fatal error %s", err)
+	}
 	err = t.Commit()
 	if err != nil {
 		hllogs.Log.Fatalf("Fatal error %s", err)
 	}
-	works := make(chan *mutation, 10240)
-	flushchan := make(chan *sync.WaitGroup, 10)
-	bs := &BoltStore{dbdir, dbname, db, bucketn, buckets, works, flushchan}
+	works := make(chan *mutation, queuelen)
+	flushchan := make(chan chan error, 10)
+	bs := &BoltStore{dbdir: dbdir, dbname: dbname, db: db, bucketn: bucketn, works: works,
+		flush: flushchan, stop: make(chan chan error), errlock: &sync.RWMutex{}}
 	hllogs.Log.Infof("Initaialized hyperlog store %s", dbpath)
 	go bs.writeToDb()
 	return bs
 }
 
-func (bs *BoltStore) Update(key string, expiry uint64, value []byte) bool {
-	buf := bytes.NewBuffer(nil)
-	binary.Write(buf, binary.LittleEndian, expiry)
-	n, err := buf.Write(value)
-	if n != len(value) || err != nil {
-		return false
+func encodeValue(expiry uint64, value []byte) []byte {
+	ret := make([]byte, vALHDRLEN+len(value))
+	binary.LittleEndian.PutUint64(ret, expiry&eXPIRYMASK|uint64(vALVERSION)<<56)
+	copy(ret[vALHDRLEN:], value)
+	crc := crc32.Update(crc32.Checksum(ret[:8], crcTable), crcTable, value)
+	binary.LittleEndian.PutUint32(ret[8:], crc)
+	return ret
+}
+
+// Returns the expiry in milliseconds and the data of a stored value
+func decodeValue(val []byte) (uint64, []byte, error) {
+	if len(val) <= 8 {
+		return 0, nil, ErrInvalidValue
+	}
+	hdr := binary.LittleEndian.Uint64(val)
+	switch hdr >> 56 {
+	case 0:
+		return secondsToMillis(hdr), val[8:], nil
+	case 1, vALVERSION:
+		if len(val) <= vALHDRLEN {
+			return 0, nil, ErrInvalidValue
+		}
+		crc := crc32.Update(crc32.Checksum(val[:8], crcTable), crcTable, val[vALHDRLEN:])
+		if crc != binary.LittleEndian.Uint32(val[8:]) {
+			return 0, nil, ErrChecksum
+		}
+		if hdr>>56 == 1 {
+			return secondsToMillis(hdr & eXPIRYMASK), val[vALHDRLEN:], nil
+		}
+		return hdr & eXPIRYMASK, val[vALHDRLEN:], nil
+	}
+	return 0, nil, ErrValueVersion
+}
+
+// Converts a legacy expiry in seconds, capped at MaxExpiry
+func secondsToMillis(expiry uint64) uint64 {
+	if expiry > MaxExpiry/1000 {
+		return MaxExpiry
+	}
+	return expiry * 1000
+}
+
+func (bs *BoltStore) Update(key string, expiry uint64, value []byte) error {
+	if len(value)+vALHDRLEN > bolt.MaxValueSize {
+		return ErrInvalidValue
 	}
-	bs.works <- &mutation{UPD, uint16(crc32.ChecksumIEEE([]byte(key)) & 7), []byte(key),
-		buf.Bytes()}
-	return true
+	return bs.enqueue(&mutation{UPD, uint16(crc32.ChecksumIEEE([]byte(key)) & 7), []byte(key),
+		encodeValue(expiry, value)})
 }
 
-func (bs *BoltStore) Delete(key string) {
-	bs.works <- &mutation{DEL, uint16(crc32.ChecksumIEEE([]byte(key)) & 7), []byte(key), nil}
+func (bs *BoltStore) Delete(key string) error {
+	return bs.enqueue(&mutation{DEL, uint16(crc32.ChecksumIEEE([]byte(key)) & 7), []byte(key),
+		nil})
 }
 
-func (bs *BoltStore) processBucket(bkt *bolt.Bucket, processor KeyValProcessor) (error, bool) {
+func (bs *BoltStore) enqueue(mut *mutation) error {
+	if len(mut.key) == 0 || len(mut.key) > bolt.MaxKeySize {
+		return ErrInvalidKey
+	}
+	select {
+	case bs.works <- mut:
+		return nil
+	default:
+		return ErrOverloaded
+	}
+}
+
+// Processes all the keys of the bucket, returns the keys which are corrupt or
+// failed to process
+func (bs *BoltStore) processBucket(bkt *bolt.Bucket, processor KeyValProcessor) []QuarantinedKey {
+	var bad []QuarantinedKey
 	cursor := bkt.Cursor()
-	var expiry uint64
-	ret := true
-	var err error = nil
 	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
-		if len(v) <= 8 {
-			return errors.New("Invalid data"), true
-		}
-		rd := bytes.NewReader(v)
-		err = binary.Read(rd, binary.LittleEndian, &expiry)
-		if err != nil {
-			return err, true
+		expiry, data, err := decodeValue(v)
+		if err == nil {
+			err = processor.Process(string(k), expiry, data)
 		}
-		err = processor.Process(string(k), expiry, v[8:])
 		if err != nil {
-			return err, false
+			bad = append(bad, QuarantinedKey{Key: string(k), Reason: err.Error(),
+				Time: time.Now().Unix(), Value: append([]byte(nil), v...)})
 		}
 	}
-	return err, ret
+	return bad
 }
 
+// Moves the keys from the bucket to the quarantine bucket
+func (bs *BoltStore) quarantine(bktn string, bad []QuarantinedKey) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(bktn))
+		qbkt := tx.Bucket([]byte(qUARANTINEBKT))
+		for _, qk := range bad {
+			val := make([]byte, 10+len(qk.Reason)+len(qk.Value))
+			binary.LittleEndian.PutUint64(val, uint64(qk.Time))
+			binary.LittleEndian.PutUint16(val[8:], uint16(len(qk.Reason)))
+			copy(val[10:], qk.Reason)
+			copy(val[10+len(qk.Reason):], qk.Value)
+			if err := qbkt.Put([]byte(qk.Key), val); err != nil {
+				return err
+			}
+			if err := bkt.Delete([]byte(qk.Key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Processes all the keys in the store. Keys with a corrupt value or which the
+// processor fails to process are moved to the quarantine bucket and logged,
+// the restore goes on with the other keys
 func (bs *BoltStore) ProcessAll(processor KeyValProcessor) error {
-	var err error
-	var ret bool
+	numbad := 0
 	for _, bktn := range bs.bucketn {
-		tx, err := bs.db.Begin(false)
-		bkt := tx.Bucket([]byte(bktn))
-		if bkt != nil {
-			err, ret = bs.processBucket(bkt, processor)
+		var bad []QuarantinedKey
+		err := bs.db.View(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket([]byte(bktn))
+			if bkt != nil {
+				bad = bs.processBucket(bkt, processor)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-		tx.Rollback()
-		if err != nil || !ret {
-			break
+		if len(bad) == 0 {
+			continue
+		}
+		for _, qk := range bad {
+			hllogs.Log.Errorf("Quarantining key %s: %s", qk.Key, qk.Reason)
 		}
+		if err = bs.quarantine(bktn, bad); err != nil {
+			return err
+		}
+		numbad += len(bad)
 	}
-	return err
+	if numbad > 0 {
+		hllogs.Log.Errorf("Quarantined %d keys during restore", numbad)
+	}
+	return nil
+}
+
+// Returns the keys in the quarantine bucket
+func (bs *BoltStore) Quarantined() ([]QuarantinedKey, error) {
+	var ret []QuarantinedKey
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(qUARANTINEBKT)).ForEach(func(k []byte, v []byte) error {
+			if len(v) < 10 {
+				return ErrInvalidValue
+			}
+			reasonlen := 10 + int(binary.LittleEndian.Uint16(v[8:]))
+			if len(v) < reasonlen {
+				return ErrInvalidValue
+			}
+			ret = append(ret, QuarantinedKey{Key: string(k),
+				Time:   int64(binary.LittleEndian.Uint64(v)),
+				Reason: string(v[10:reasonlen]),
+				Value:  append([]byte(nil), v[reasonlen:]...)})
+			return nil
+		})
+	})
+	return ret, err
 }
 
 func (bs *BoltStore) Get(key string) ([]byte, uint64, error) {
@@ -143,14 +286,15 @@ func (bs *BoltStore) Get(key string) ([]byte, uint64, error) {
 		return nil, 0, errors.New("Bucket not found")
 	}
 	val := bkt.Get([]byte(key))
-	if val == nil || len(val) <= 8 {
-		return nil, 0, errors.New("Key not exists or invalid value for key")
+	if val == nil {
+		return nil, 0, ErrKeyNotExists
 	}
-	var expiry uint64
-	rd := bytes.NewReader(val)
-	binary.Read(rd, binary.LittleEndian, &expiry)
-	retval := make([]byte, len(val)-8)
-	copy(retval, val[8:])
+	expiry, data, err := decodeValue(val)
+	if err != nil {
+		return nil, 0, err
+	}
+	retval := make([]byte, len(data))
+	copy(retval, data)
 	return retval, expiry, nil
 }
 
@@ -167,91 +311,116 @@ func (bs *BoltStore) GetExpiry(key string) (uint64, error) {
 		return 0, errors.New("Bucket not found")
 	}
 	val := bkt.Get([]byte(key))
-	if val == nil || len(val) <= 8 {
-		return 0, errors.New("Key not exists or invalid value for key")
+	if val == nil {
+		return 0, ErrKeyNotExists
 	}
-	var expiry uint64
-	rd := bytes.NewReader(val)
-	binary.Read(rd, binary.LittleEndian, &expiry)
-	return expiry, nil
+	expiry, _, err := decodeValue(val)
+	return expiry, err
 }
 
-func (bs *BoltStore) initTransactions() (*bolt.Tx, error) {
-	tx, err := bs.db.Begin(true)
-	if err != nil {
-		return nil, err
-	}
-	i := 0
-	for i < 8 {
-		bs.buckets[i] = tx.Bucket([]byte(bs.bucketn[i]))
-		i++
-	}
-	return tx, nil
+// Commits the mutations in one transaction
+func (bs *BoltStore) commitBatch(batch []*mutation) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		for _, mut := range batch {
+			bkt := tx.Bucket([]byte(bs.bucketn[mut.bktn]))
+			var err error
+			if mut.op == UPD {
+				err = bkt.Put(mut.key, mut.value)
+			} else {
+				err = bkt.Delete(mut.key)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (bs *BoltStore) dbUpdate(tx *bolt.Tx, key []byte, value []byte, bktn uint32) error {
-	return bs.buckets[bktn].Put(key, value)
+func (bs *BoltStore) setErr(err error) {
+	bs.errlock.Lock()
+	defer bs.errlock.Unlock()
+	bs.err = err
 }
 
-func (bs *BoltStore) dbDelete(tx *bolt.Tx, key []byte, bktn uint32) error {
-	return bs.buckets[bktn].Delete(key)
+// Returns the error of the last failed commit, nil once a commit succeeds
+func (bs *BoltStore) Err() error {
+	bs.errlock.RLock()
+	defer bs.errlock.RUnlock()
+	return bs.err
 }
 
 func (bs *BoltStore) writeToDb() {
-	var added uint32 = 0
-	commited := true
+	// mutations not yet committed, a failed commit is retried on the next
+	// tick with the same mutations
+	var batch []*mutation
 	timer := time.NewTicker(1 * time.Second)
-	var tx *bolt.Tx
-	var err error
+	commit := func() error {
+		if len(batch) == 0 {
+			return bs.Err()
+		}
+		err := bs.commitBatch(batch)
+		bs.setErr(err)
+		if err != nil {
+			hllogs.Log.Errorf("Failed to commit %d mutations, will retry: %s", len(batch), err)
+			return err
+		}
+		batch = nil
+		return nil
+	}
+	// the mutations queued before a flush or stop must be committed too
+	commitAll := func() error {
+		for {
+			select {
+			case mut := <-bs.works:
+				batch = append(batch, mut)
+				continue
+			default:
+			}
+			break
+		}
+		return commit()
+	}
 	for {
+		// while commits fail no more mutations are taken, the queue fills up
+		// and the store reports that it is overloaded
+		works := bs.works
+		if len(batch) > 0 && bs.Err() != nil {
+			works = nil
+		}
 		select {
 		case _ = <-timer.C:
-			if added > 0 {
-				tx.Commit()
-				commited = true
-				added = 0
-			}
-		case mut := <-bs.works:
-			added++
-			if commited {
-				tx, err = bs.initTransactions()
-				if err != nil {
-					hllogs.Log.Fatalf("Fatal error %s", err)
-				}
-				commited = false
+			commit()
+		case mut := <-works:
+			batch = append(batch, mut)
+			if len(batch) > 10000 {
+				commit()
 			}
-			if mut.op == UPD {
-				err = bs.dbUpdate(tx, mut.key, mut.value, uint32(mut.bktn))
-			} else {
-				err = bs.dbDelete(tx, mut.key, uint32(mut.bktn))
-			}
-			if err != nil {
-				hllogs.Log.Fatalf("Fatal error %s", err)
-			}
-			if added > 10000 {
-				tx.Commit()
-				commited = true
-				added = 0
-			}
-		case wg := <-bs.flush:
-			if added > 0 {
-				tx.Commit()
-				commited = true
-				added = 0
-			}
-			wg.Done()
+		case done := <-bs.flush:
+			done <- commitAll()
+		case done := <-bs.stop:
+			done <- commitAll()
+			timer.Stop()
+			return
 		}
 	}
 }
 
-func (bs *BoltStore) Flush() {
-	var wg sync.WaitGroup
-	wg.Add(1)
-	bs.flush <- &wg
-	wg.Wait()
+// Commits the queued mutations, returns the error if the commit fails
+func (bs *BoltStore) Flush() error {
+	done := make(chan error, 1)
+	bs.flush <- done
+	return <-done
 }
 
-func (bs *BoltStore) FlushAndStop() {
-	time.Sleep(2 * time.Second)
-	bs.db.Close()
+// Commits the queued mutations and closes the db, the store must not be used
+// afterwards
+func (bs *BoltStore) FlushAndStop() error {
+	done := make(chan error, 1)
+	bs.stop <- done
+	err := <-done
+	if cerr := bs.db.Close(); err == nil {
+		err = cerr
+	}
+	return err
 }
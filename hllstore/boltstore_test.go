@@ -1,10 +1,16 @@
 package hllstore
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"github.com/boltdb/bolt"
 	"github.com/nipuntalukdar/hllserver/hllogs"
+	"hash/crc32"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 )
@@ -70,3 +76,205 @@ func TestBoltStore(t *testing.T) {
 	os.Remove("/tmp/mybolt.db")
 
 }
+
+type recordingProc struct {
+	keys   []string
+	reject string
+}
+
+func (proc *recordingProc) Process(key string, expiry uint64, value []byte) error {
+	if key == proc.reject {
+		return errors.New("Error in decoding hyperlog")
+	}
+	proc.keys = append(proc.keys, key)
+	return nil
+}
+
+func putRaw(t *testing.T, bs *BoltStore, key string, val []byte) {
+	err := bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bs.bucketn[crc32.ChecksumIEEE([]byte(key))&7])).Put(
+			[]byte(key), val)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBoltStoreQuarantine(t *testing.T) {
+	os.Remove("/tmp/quarantine.db")
+	defer os.Remove("/tmp/quarantine.db")
+	bs := NewBoltStore("/tmp", "quarantine.db")
+	for i := 0; i < 10; i++ {
+		bs.Update(fmt.Sprintf("good%d", i), uint64(i), []byte("value"))
+	}
+	bs.Update("reject", 0, []byte("value"))
+	bs.Flush()
+	legacy := make([]byte, 8, 14)
+	binary.LittleEndian.PutUint64(legacy, 1234)
+	putRaw(t, bs, "legacy", append(legacy, "legacy"...))
+	// version 1 values have the expiry in seconds
+	v1 := binary.LittleEndian.AppendUint64(nil, 1<<56|1234)
+	crc := crc32.Update(crc32.Checksum(v1, crcTable), crcTable, []byte("v1"))
+	putRaw(t, bs, "v1", append(binary.LittleEndian.AppendUint32(v1, crc), "v1"...))
+	putRaw(t, bs, "short", []byte{1, 2, 3})
+	corrupt := encodeValue(5, []byte("value"))
+	corrupt[len(corrupt)-1] ^= 1
+	putRaw(t, bs, "corrupt", corrupt)
+	future := encodeValue(5, []byte("value"))
+	future[7] = vALVERSION + 1
+	putRaw(t, bs, "future", future)
+	bs.FlushAndStop()
+
+	bs = NewBoltStore("/tmp", "quarantine.db")
+	proc := &recordingProc{reject: "reject"}
+	if err := bs.ProcessAll(proc); err != nil {
+		t.Fatalf("Restore must go on past bad keys %s", err)
+	}
+	if len(proc.keys) != 12 {
+		t.Fatalf("Unexpected processed keys %v", proc.keys)
+	}
+	data, exp, err := bs.Get("legacy")
+	if err != nil || exp != 1234000 || string(data) != "legacy" {
+		t.Fatal("Values without checksum must be read")
+	}
+	data, exp, err = bs.Get("v1")
+	if err != nil || exp != 1234000 || string(data) != "v1" {
+		t.Fatal("Values with expiry in seconds must be read")
+	}
+	qkeys, err := bs.Quarantined()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(qkeys, func(i, j int) bool { return qkeys[i].Key < qkeys[j].Key })
+	reasons := map[string]string{"corrupt": ErrChecksum.Error(),
+		"future": ErrValueVersion.Error(), "reject": "Error in decoding hyperlog",
+		"short": ErrInvalidValue.Error()}
+	if len(qkeys) != len(reasons) {
+		t.Fatalf("Unexpected quarantined keys %v", qkeys)
+	}
+	for _, qk := range qkeys {
+		if reasons[qk.Key] != qk.Reason || qk.Time == 0 {
+			t.Fatalf("Unexpected quarantined key %+v", qk)
+		}
+	}
+	if !reflect.DeepEqual(qkeys[0].Value, corrupt) {
+		t.Fatal("Quarantined value must be kept as it was")
+	}
+	if _, _, err = bs.Get("corrupt"); err == nil {
+		t.Fatal("Quarantined key must be removed from the store")
+	}
+	bs.FlushAndStop()
+
+	bs = NewBoltStore("/tmp", "quarantine.db")
+	proc = &recordingProc{}
+	bs.ProcessAll(proc)
+	if len(proc.keys) != 12 {
+		t.Fatalf("Unexpected processed keys %v", proc.keys)
+	}
+	if qkeys, _ = bs.Quarantined(); len(qkeys) != len(reasons) {
+		t.Fatalf("Unexpected quarantined keys %v", qkeys)
+	}
+	bs.FlushAndStop()
+}
+
+func TestWal(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := OpenWal(dir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records [][]byte
+	for i := 0; i < 100; i++ {
+		record := []byte(fmt.Sprintf("record%d", i))
+		records = append(records, record)
+		seq := wal.Append(record)
+		if i%10 == 9 {
+			if err = wal.Wait(seq); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if i == 49 {
+			if sealed, err := wal.Rotate(); err != nil || sealed != 1 {
+				t.Fatalf("Unexpected sealed segment %d %v", sealed, err)
+			}
+		}
+	}
+	wal.Close()
+	if wal.Wait(wal.Append([]byte("late"))) != ErrWalClosed {
+		t.Fatal("Records appended after close must not be durable")
+	}
+	// a torn record at the tail of the last segment
+	file, err := os.OpenFile(filepath.Join(dir, fmt.Sprintf("%016x%s", 2, wALSUFFIX)),
+		os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte{20, 0, 0, 0, 1, 2, 3, 4, 'x'})
+	file.Close()
+
+	wal, err = OpenWal(dir, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wal.Append([]byte("not replayed"))
+	wal.Sync()
+	var replayed [][]byte
+	last, err := wal.Replay(func(record []byte) error {
+		replayed = append(replayed, append([]byte(nil), record...))
+		return nil
+	})
+	if err != nil || last != 2 || !reflect.DeepEqual(replayed, records) {
+		t.Fatalf("Unexpected replay of %d records, last segment %d %v", len(replayed), last, err)
+	}
+	if err = wal.Truncate(last); err != nil {
+		t.Fatal(err)
+	}
+	if segments, _ := wal.segments(); !reflect.DeepEqual(segments, []uint64{3}) {
+		t.Fatalf("Unexpected segments after truncate %v", segments)
+	}
+	wal.Close()
+}
+
+func TestBoltStoreFlushAndStop(t *testing.T) {
+	dir := t.TempDir()
+	bs := NewBoltStore(dir, "stop.db")
+	for i := 0; i < 1000; i++ {
+		bs.Update(fmt.Sprintf("key%d", i), 0, []byte("value"))
+	}
+	bs.Delete("key0")
+	bs.FlushAndStop()
+	bs = NewBoltStore(dir, "stop.db")
+	proc := &recordingProc{}
+	bs.ProcessAll(proc)
+	if len(proc.keys) != 999 {
+		t.Fatalf("Queued mutations must be committed on stop, found %d keys", len(proc.keys))
+	}
+	bs.FlushAndStop()
+}
+
+func TestBoltStoreOverload(t *testing.T) {
+	bs := NewBoltStoreWithQueue(t.TempDir(), "overload.db", 1)
+	defer bs.FlushAndStop()
+	if bs.Update("", 0, []byte("value")) != ErrInvalidKey || bs.Delete("") != ErrInvalidKey {
+		t.Fatal("Empty key must be rejected")
+	}
+	// the writer blocks on the db while the transaction is open
+	tx, err := bs.db.Begin(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overloaded := false
+	for i := 0; i < 20000 && !overloaded; i++ {
+		overloaded = bs.Update(fmt.Sprintf("key%d", i), 0, []byte("value")) == ErrOverloaded
+	}
+	tx.Rollback()
+	if !overloaded {
+		t.Fatal("Updates must fail when the queue is full")
+	}
+	if err = bs.Flush(); err != nil || bs.Err() != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if bs.Update("key", 0, []byte("value")) != nil {
+		t.Fatal("Update must succeed after the queue is drained")
+	}
+}
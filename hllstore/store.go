@@ -5,16 +5,31 @@ type KeyExpiry struct {
 	Expiry uint64
 }
 
+// A key moved out of the store on restore because its record was corrupt or
+// couldn't be processed, the record is kept as it was
+type QuarantinedKey struct {
+	Key    string
+	Reason string
+	Time   int64
+	Value  []byte
+}
+
 type KeyValProcessor interface {
 	Process(key string, expiry uint64, value []byte) error
 }
 
+// Update and Delete may apply asynchronously, they fail with ErrOverloaded
+// when the store can't keep up. Err returns the error which keeps the store
+// from applying them, nil if it is healthy. Expiries are unix times in
+// milliseconds, 0 for no expiry, at most MaxExpiry
 type HllStore interface {
-	Update(key string, expiry uint64, value []byte) bool
-	Delete(key string)
+	Update(key string, expiry uint64, value []byte) error
+	Delete(key string) error
 	ProcessAll(processor KeyValProcessor) error
 	Get(key string) ([]byte, uint64, error)
 	GetExpiry(key string) (uint64, error)
-	FlushAndStop()
-	Flush()
+	FlushAndStop() error
+	Flush() error
+	Err() error
+	Quarantined() ([]QuarantinedKey, error)
 }
@@ -0,0 +1,329 @@
+package hllstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/nipuntalukdar/hllserver/hllogs"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The write ahead log is a sequence of segment files named by the hex segment
+// id in a directory. A record is framed as 4 bytes little endian length, 4
+// bytes crc32c of the payload and the payload. The appended records are
+// written and fsynced together once every sync interval
+const (
+	wALFRAMELEN        = 8
+	wALSUFFIX          = ".wal"
+	wALMAXRECORD       = 64 * 1024 * 1024
+	wALFILEMODE        = 0644
+	wALDIRMODE         = 0755
+	wALMININTERVAL     = time.Millisecond
+	wALDEFAULTINTERVAL = 10 * time.Millisecond
+)
+
+var ErrWalClosed = errors.New("Write ahead log is closed")
+
+type Wal struct {
+	dir  string
+	lock *sync.Mutex
+	cond *sync.Cond
+	// records appended since the last sync
+	buf []byte
+	// sequence numbers of the last appended and the last durable record
+	seq     uint64
+	syncseq uint64
+	// the first write error, no record is durable after it
+	err error
+	// serializes the writes to the segment files
+	wlock   *sync.Mutex
+	segment uint64
+	file    *os.File
+	stop    chan bool
+	stopped chan bool
+}
+
+// Opens the write ahead log in the directory, creating the directory if it
+// doesn't exist. The existing segments are kept for Replay, new records go to
+// a new segment
+func OpenWal(dir string, interval time.Duration) (*Wal, error) {
+	if interval < wALMININTERVAL {
+		interval = wALDEFAULTINTERVAL
+	}
+	if err := os.MkdirAll(dir, wALDIRMODE); err != nil {
+		return nil, err
+	}
+	lock := &sync.Mutex{}
+	w := &Wal{dir: dir, lock: lock, cond: sync.NewCond(lock), wlock: &sync.Mutex{},
+		stop: make(chan bool), stopped: make(chan bool)}
+	segments, err := w.segments()
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 0 {
+		w.segment = segments[len(segments)-1]
+	}
+	if err = w.openSegment(w.segment + 1); err != nil {
+		return nil, err
+	}
+	hllogs.Log.Infof("Opened write ahead log %s, %d segments to replay", dir, len(segments))
+	go w.syncer(interval)
+	return w, nil
+}
+
+func (w *Wal) segmentPath(id uint64) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%016x%s", id, wALSUFFIX))
+}
+
+// Returns the ids of the segments in the directory in increasing order
+func (w *Wal) segments() ([]uint64, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, wALSUFFIX) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, wALSUFFIX), 16, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// Must be called with wlock held or before the log is shared
+func (w *Wal) openSegment(id uint64) error {
+	file, err := os.OpenFile(w.segmentPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND,
+		wALFILEMODE)
+	if err != nil {
+		return err
+	}
+	if err = w.syncDir(); err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.segment = id
+	return nil
+}
+
+// fsyncs the directory so that created and removed segments are durable
+func (w *Wal) syncDir() error {
+	dir, err := os.Open(w.dir)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// Appends the record, returns its sequence number for Wait. The record is
+// durable only after the next sync
+func (w *Wal) Append(record []byte) uint64 {
+	var frame [wALFRAMELEN]byte
+	binary.LittleEndian.PutUint32(frame[:], uint32(len(record)))
+	binary.LittleEndian.PutUint32(frame[4:], crc32.Checksum(record, crcTable))
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.buf = append(w.buf, frame[:]...)
+	w.buf = append(w.buf, record...)
+	w.seq++
+	return w.seq
+}
+
+// Returns the sequence number of the last appended record
+func (w *Wal) Seq() uint64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.seq
+}
+
+// Blocks until the record with the sequence number is durable, returns an
+// error if it can't be made durable
+func (w *Wal) Wait(seq uint64) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for w.syncseq < seq && w.err == nil {
+		w.cond.Wait()
+	}
+	if w.syncseq >= seq {
+		return nil
+	}
+	return w.err
+}
+
+// Returns the first write error of the log, nil if all the records synced so
+// far are durable
+func (w *Wal) Err() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.err
+}
+
+// Writes and fsyncs the appended records
+func (w *Wal) Sync() {
+	w.wlock.Lock()
+	defer w.wlock.Unlock()
+	w.sync()
+}
+
+// Must be called with wlock held
+func (w *Wal) sync() {
+	w.lock.Lock()
+	buf, seq, err := w.buf, w.seq, w.err
+	w.buf = nil
+	w.lock.Unlock()
+	if err != nil || w.file == nil {
+		return
+	}
+	if len(buf) > 0 {
+		if _, err = w.file.Write(buf); err == nil {
+			err = w.file.Sync()
+		}
+	}
+	w.lock.Lock()
+	if err != nil {
+		hllogs.Log.Errorf("Write ahead log %s failed: %s", w.dir, err)
+		w.err = err
+	} else {
+		w.syncseq = seq
+	}
+	w.cond.Broadcast()
+	w.lock.Unlock()
+}
+
+func (w *Wal) syncer(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.Sync()
+		case <-w.stop:
+			close(w.stopped)
+			return
+		}
+	}
+}
+
+// Syncs the current segment and starts a new one. Returns the id of the sealed
+// segment, all the records appended before Rotate are in it or older segments
+func (w *Wal) Rotate() (uint64, error) {
+	w.wlock.Lock()
+	defer w.wlock.Unlock()
+	w.sync()
+	if err := w.Err(); err != nil {
+		return 0, err
+	}
+	sealed := w.segment
+	if err := w.file.Close(); err != nil {
+		return 0, err
+	}
+	w.file = nil
+	if err := w.openSegment(sealed + 1); err != nil {
+		w.lock.Lock()
+		w.err = err
+		w.cond.Broadcast()
+		w.lock.Unlock()
+		return 0, err
+	}
+	return sealed, nil
+}
+
+// Removes the segments up to and including the segment id, the current
+// segment is never removed
+func (w *Wal) Truncate(upto uint64) error {
+	w.wlock.Lock()
+	defer w.wlock.Unlock()
+	segments, err := w.segments()
+	if err != nil {
+		return err
+	}
+	for _, id := range segments {
+		if id > upto || id >= w.segment {
+			break
+		}
+		if err = os.Remove(w.segmentPath(id)); err != nil {
+			return err
+		}
+	}
+	return w.syncDir()
+}
+
+// Calls fn for each record in the segments written before the log was opened,
+// in the order they were appended. A torn or corrupt record ends the replay of
+// its segment. Returns the id of the last replayed segment
+func (w *Wal) Replay(fn func(record []byte) error) (uint64, error) {
+	segments, err := w.segments()
+	if err != nil {
+		return 0, err
+	}
+	last := uint64(0)
+	for _, id := range segments {
+		if id >= w.segment {
+			break
+		}
+		data, err := os.ReadFile(w.segmentPath(id))
+		if err != nil {
+			return last, err
+		}
+		pos := 0
+		for pos < len(data) {
+			if len(data)-pos < wALFRAMELEN {
+				break
+			}
+			reclen := int(binary.LittleEndian.Uint32(data[pos:]))
+			crc := binary.LittleEndian.Uint32(data[pos+4:])
+			start := pos + wALFRAMELEN
+			if reclen > wALMAXRECORD || len(data)-start < reclen ||
+				crc32.Checksum(data[start:start+reclen], crcTable) != crc {
+				break
+			}
+			if err = fn(data[start : start+reclen]); err != nil {
+				return last, err
+			}
+			pos = start + reclen
+		}
+		if pos < len(data) {
+			hllogs.Log.Errorf("Torn or corrupt record at offset %d of %s, skipped %d bytes",
+				pos, w.segmentPath(id), len(data)-pos)
+		}
+		last = id
+	}
+	return last, nil
+}
+
+// Syncs the appended records and closes the log, records appended afterwards
+// are never durable
+func (w *Wal) Close() error {
+	close(w.stop)
+	<-w.stopped
+	w.wlock.Lock()
+	defer w.wlock.Unlock()
+	w.sync()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.lock.Lock()
+	if w.err == nil {
+		w.err = ErrWalClosed
+	}
+	w.cond.Broadcast()
+	w.lock.Unlock()
+	return err
+}
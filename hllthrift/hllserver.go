@@ -87,12 +87,81 @@ func (p *Status) Value() (driver.Value, error) {
 	return int64(*p), nil
 }
 
+type Algorithm int64
+
+const (
+	Algorithm_CLASSIC Algorithm = 0
+	Algorithm_HLLPP   Algorithm = 1
+	Algorithm_REDIS   Algorithm = 2
+)
+
+func (p Algorithm) String() string {
+	switch p {
+	case Algorithm_CLASSIC:
+		return "CLASSIC"
+	case Algorithm_HLLPP:
+		return "HLLPP"
+	case Algorithm_REDIS:
+		return "REDIS"
+	}
+	return "<UNSET>"
+}
+
+func AlgorithmFromString(s string) (Algorithm, error) {
+	switch s {
+	case "CLASSIC":
+		return Algorithm_CLASSIC, nil
+	case "HLLPP":
+		return Algorithm_HLLPP, nil
+	case "REDIS":
+		return Algorithm_REDIS, nil
+	}
+	return Algorithm(0), fmt.Errorf("not a valid Algorithm string")
+}
+
+func AlgorithmPtr(v Algorithm) *Algorithm { return &v }
+
+func (p Algorithm) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Algorithm) UnmarshalText(text []byte) error {
+	q, err := AlgorithmFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+func (p *Algorithm) Scan(value interface{}) error {
+	v, ok := value.(int64)
+	if !ok {
+		return errors.New("Scan value is not int64")
+	}
+	*p = Algorithm(v)
+	return nil
+}
+
+func (p *Algorithm) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
 // Attributes:
 //   - Key
 //   - Expiry
+//   - Precision
+//   - Algorithm
+//   - Salt
 type AddLogCmd struct {
-	Key    string `thrift:"Key,1" db:"Key" json:"Key"`
-	Expiry int64  `thrift:"Expiry,2" db:"Expiry" json:"Expiry"`
+	Key       string    `thrift:"Key,1" db:"Key" json:"Key"`
+	Expiry    int64     `thrift:"Expiry,2" db:"Expiry" json:"Expiry"`
+	Precision int32     `thrift:"Precision,3" db:"Precision" json:"Precision"`
+	Algorithm Algorithm `thrift:"Algorithm,4" db:"Algorithm" json:"Algorithm"`
+	Salt      []byte    `thrift:"Salt,5" db:"Salt" json:"Salt"`
 }
 
 func NewAddLogCmd() *AddLogCmd {
@@ -106,6 +175,18 @@ func (p *AddLogCmd) GetKey() string {
 func (p *AddLogCmd) GetExpiry() int64 {
 	return p.Expiry
 }
+
+func (p *AddLogCmd) GetPrecision() int32 {
+	return p.Precision
+}
+
+func (p *AddLogCmd) GetAlgorithm() Algorithm {
+	return p.Algorithm
+}
+
+func (p *AddLogCmd) GetSalt() []byte {
+	return p.Salt
+}
 func (p *AddLogCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -140,6 +221,36 @@ func (p *AddLogCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
 					return err
 				}
 			}
+		case 3:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 4:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField4(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 5:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField5(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
 		default:
 			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
 				return err
@@ -173,6 +284,34 @@ func (p *AddLogCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) erro
 	return nil
 }
 
+func (p *AddLogCmd) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Precision = v
+	}
+	return nil
+}
+
+func (p *AddLogCmd) ReadField4(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		temp := Algorithm(v)
+		p.Algorithm = temp
+	}
+	return nil
+}
+
+func (p *AddLogCmd) ReadField5(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(ctx); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Salt = v
+	}
+	return nil
+}
+
 func (p *AddLogCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin(ctx, "AddLogCmd"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -184,6 +323,15 @@ func (p *AddLogCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
 		if err := p.writeField2(ctx, oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(ctx, oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(ctx); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -220,6 +368,45 @@ func (p *AddLogCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (er
 	return err
 }
 
+func (p *AddLogCmd) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Precision", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Precision: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Precision)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Precision (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Precision: ", p), err)
+	}
+	return err
+}
+
+func (p *AddLogCmd) writeField4(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Algorithm", thrift.I32, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Algorithm: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Algorithm)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Algorithm (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Algorithm: ", p), err)
+	}
+	return err
+}
+
+func (p *AddLogCmd) writeField5(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Salt", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Salt: ", p), err)
+	}
+	if err := oprot.WriteBinary(ctx, p.Salt); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Salt (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Salt: ", p), err)
+	}
+	return err
+}
+
 func (p *AddLogCmd) Equals(other *AddLogCmd) bool {
 	if p == other {
 		return true
@@ -232,6 +419,15 @@ func (p *AddLogCmd) Equals(other *AddLogCmd) bool {
 	if p.Expiry != other.Expiry {
 		return false
 	}
+	if p.Precision != other.Precision {
+		return false
+	}
+	if p.Algorithm != other.Algorithm {
+		return false
+	}
+	if bytes.Compare(p.Salt, other.Salt) != 0 {
+		return false
+	}
 	return true
 }
 
@@ -669,24 +865,30 @@ func (p *UpdateLogMValCmd) String() string {
 
 // Attributes:
 //   - Key
+//   - Hashes
 //   - Expiry
-type UpdateExpiryCmd struct {
-	Key    string `thrift:"Key,1" db:"Key" json:"Key"`
-	Expiry int64  `thrift:"Expiry,2" db:"Expiry" json:"Expiry"`
+type UpdateLogHashesCmd struct {
+	Key    string  `thrift:"Key,1" db:"Key" json:"Key"`
+	Hashes []int64 `thrift:"Hashes,2" db:"Hashes" json:"Hashes"`
+	Expiry int64   `thrift:"Expiry,3" db:"Expiry" json:"Expiry"`
 }
 
-func NewUpdateExpiryCmd() *UpdateExpiryCmd {
-	return &UpdateExpiryCmd{}
+func NewUpdateLogHashesCmd() *UpdateLogHashesCmd {
+	return &UpdateLogHashesCmd{}
 }
 
-func (p *UpdateExpiryCmd) GetKey() string {
+func (p *UpdateLogHashesCmd) GetKey() string {
 	return p.Key
 }
 
-func (p *UpdateExpiryCmd) GetExpiry() int64 {
+func (p *UpdateLogHashesCmd) GetHashes() []int64 {
+	return p.Hashes
+}
+
+func (p *UpdateLogHashesCmd) GetExpiry() int64 {
 	return p.Expiry
 }
-func (p *UpdateExpiryCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *UpdateLogHashesCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -711,7 +913,7 @@ func (p *UpdateExpiryCmd) Read(ctx context.Context, iprot thrift.TProtocol) erro
 				}
 			}
 		case 2:
-			if fieldTypeId == thrift.I64 {
+			if fieldTypeId == thrift.LIST {
 				if err := p.ReadField2(ctx, iprot); err != nil {
 					return err
 				}
@@ -720,6 +922,16 @@ func (p *UpdateExpiryCmd) Read(ctx context.Context, iprot thrift.TProtocol) erro
 					return err
 				}
 			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
 		default:
 			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
 				return err
@@ -735,7 +947,7 @@ func (p *UpdateExpiryCmd) Read(ctx context.Context, iprot thrift.TProtocol) erro
 	return nil
 }
 
-func (p *UpdateExpiryCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *UpdateLogHashesCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(ctx); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
@@ -744,17 +956,39 @@ func (p *UpdateExpiryCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol
 	return nil
 }
 
-func (p *UpdateExpiryCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *UpdateLogHashesCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin(ctx)
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int64, 0, size)
+	p.Hashes = tSlice
+	for i := 0; i < size; i++ {
+		var _elem2 int64
+		if v, err := iprot.ReadI64(ctx); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem2 = v
+		}
+		p.Hashes = append(p.Hashes, _elem2)
+	}
+	if err := iprot.ReadListEnd(ctx); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *UpdateLogHashesCmd) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(ctx); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
+		return thrift.PrependError("error reading field 3: ", err)
 	} else {
 		p.Expiry = v
 	}
 	return nil
 }
 
-func (p *UpdateExpiryCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "UpdateExpiryCmd"); err != nil {
+func (p *UpdateLogHashesCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateLogHashesCmd"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -764,6 +998,9 @@ func (p *UpdateExpiryCmd) Write(ctx context.Context, oprot thrift.TProtocol) err
 		if err := p.writeField2(ctx, oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(ctx); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -774,7 +1011,7 @@ func (p *UpdateExpiryCmd) Write(ctx context.Context, oprot thrift.TProtocol) err
 	return nil
 }
 
-func (p *UpdateExpiryCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *UpdateLogHashesCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
@@ -787,20 +1024,41 @@ func (p *UpdateExpiryCmd) writeField1(ctx context.Context, oprot thrift.TProtoco
 	return err
 }
 
-func (p *UpdateExpiryCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "Expiry", thrift.I64, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Expiry: ", p), err)
+func (p *UpdateLogHashesCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Hashes", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Hashes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(ctx, thrift.I64, len(p.Hashes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Hashes {
+		if err := oprot.WriteI64(ctx, int64(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(ctx); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Hashes: ", p), err)
+	}
+	return err
+}
+
+func (p *UpdateLogHashesCmd) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Expiry", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Expiry: ", p), err)
 	}
 	if err := oprot.WriteI64(ctx, int64(p.Expiry)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Expiry (2) field write error: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T.Expiry (3) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Expiry: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Expiry: ", p), err)
 	}
 	return err
 }
 
-func (p *UpdateExpiryCmd) Equals(other *UpdateExpiryCmd) bool {
+func (p *UpdateLogHashesCmd) Equals(other *UpdateLogHashesCmd) bool {
 	if p == other {
 		return true
 	} else if p == nil || other == nil {
@@ -809,45 +1067,48 @@ func (p *UpdateExpiryCmd) Equals(other *UpdateExpiryCmd) bool {
 	if p.Key != other.Key {
 		return false
 	}
+	if len(p.Hashes) != len(other.Hashes) {
+		return false
+	}
+	for i, _tgt := range p.Hashes {
+		_src3 := other.Hashes[i]
+		if _tgt != _src3 {
+			return false
+		}
+	}
 	if p.Expiry != other.Expiry {
 		return false
 	}
 	return true
 }
 
-func (p *UpdateExpiryCmd) String() string {
+func (p *UpdateLogHashesCmd) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("UpdateExpiryCmd(%+v)", *p)
+	return fmt.Sprintf("UpdateLogHashesCmd(%+v)", *p)
 }
 
 // Attributes:
 //   - Key
-//   - Status
-//   - Cardinality
-type CardinalityResponse struct {
-	Key         string `thrift:"Key,1" db:"Key" json:"Key"`
-	Status      Status `thrift:"Status,2" db:"Status" json:"Status"`
-	Cardinality int64  `thrift:"Cardinality,3" db:"Cardinality" json:"Cardinality"`
+//   - SourceKeys
+type MergeLogCmd struct {
+	Key        string   `thrift:"Key,1" db:"Key" json:"Key"`
+	SourceKeys []string `thrift:"SourceKeys,2" db:"SourceKeys" json:"SourceKeys"`
 }
 
-func NewCardinalityResponse() *CardinalityResponse {
-	return &CardinalityResponse{}
+func NewMergeLogCmd() *MergeLogCmd {
+	return &MergeLogCmd{}
 }
 
-func (p *CardinalityResponse) GetKey() string {
+func (p *MergeLogCmd) GetKey() string {
 	return p.Key
 }
 
-func (p *CardinalityResponse) GetStatus() Status {
-	return p.Status
-}
-
-func (p *CardinalityResponse) GetCardinality() int64 {
-	return p.Cardinality
+func (p *MergeLogCmd) GetSourceKeys() []string {
+	return p.SourceKeys
 }
-func (p *CardinalityResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *MergeLogCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -872,7 +1133,7 @@ func (p *CardinalityResponse) Read(ctx context.Context, iprot thrift.TProtocol)
 				}
 			}
 		case 2:
-			if fieldTypeId == thrift.I32 {
+			if fieldTypeId == thrift.LIST {
 				if err := p.ReadField2(ctx, iprot); err != nil {
 					return err
 				}
@@ -881,16 +1142,6 @@ func (p *CardinalityResponse) Read(ctx context.Context, iprot thrift.TProtocol)
 					return err
 				}
 			}
-		case 3:
-			if fieldTypeId == thrift.I64 {
-				if err := p.ReadField3(ctx, iprot); err != nil {
-					return err
-				}
-			} else {
-				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
-					return err
-				}
-			}
 		default:
 			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
 				return err
@@ -906,7 +1157,7 @@ func (p *CardinalityResponse) Read(ctx context.Context, iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *CardinalityResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *MergeLogCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(ctx); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
@@ -915,27 +1166,30 @@ func (p *CardinalityResponse) ReadField1(ctx context.Context, iprot thrift.TProt
 	return nil
 }
 
-func (p *CardinalityResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(ctx); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		temp := Status(v)
-		p.Status = temp
+func (p *MergeLogCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin(ctx)
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	return nil
-}
-
-func (p *CardinalityResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(ctx); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.Cardinality = v
+	tSlice := make([]string, 0, size)
+	p.SourceKeys = tSlice
+	for i := 0; i < size; i++ {
+		var _elem4 string
+		if v, err := iprot.ReadString(ctx); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem4 = v
+		}
+		p.SourceKeys = append(p.SourceKeys, _elem4)
+	}
+	if err := iprot.ReadListEnd(ctx); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *CardinalityResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "CardinalityResponse"); err != nil {
+func (p *MergeLogCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "MergeLogCmd"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -945,9 +1199,6 @@ func (p *CardinalityResponse) Write(ctx context.Context, oprot thrift.TProtocol)
 		if err := p.writeField2(ctx, oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(ctx, oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(ctx); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -958,7 +1209,7 @@ func (p *CardinalityResponse) Write(ctx context.Context, oprot thrift.TProtocol)
 	return nil
 }
 
-func (p *CardinalityResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *MergeLogCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
@@ -971,33 +1222,28 @@ func (p *CardinalityResponse) writeField1(ctx context.Context, oprot thrift.TPro
 	return err
 }
 
-func (p *CardinalityResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Status: ", p), err)
-	}
-	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Status (2) field write error: ", p), err)
+func (p *MergeLogCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "SourceKeys", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:SourceKeys: ", p), err)
 	}
-	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Status: ", p), err)
+	if err := oprot.WriteListBegin(ctx, thrift.STRING, len(p.SourceKeys)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	return err
-}
-
-func (p *CardinalityResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "Cardinality", thrift.I64, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Cardinality: ", p), err)
+	for _, v := range p.SourceKeys {
+		if err := oprot.WriteString(ctx, string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
 	}
-	if err := oprot.WriteI64(ctx, int64(p.Cardinality)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Cardinality (3) field write error: ", p), err)
+	if err := oprot.WriteListEnd(ctx); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Cardinality: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:SourceKeys: ", p), err)
 	}
 	return err
 }
 
-func (p *CardinalityResponse) Equals(other *CardinalityResponse) bool {
+func (p *MergeLogCmd) Equals(other *MergeLogCmd) bool {
 	if p == other {
 		return true
 	} else if p == nil || other == nil {
@@ -1006,719 +1252,8243 @@ func (p *CardinalityResponse) Equals(other *CardinalityResponse) bool {
 	if p.Key != other.Key {
 		return false
 	}
-	if p.Status != other.Status {
+	if len(p.SourceKeys) != len(other.SourceKeys) {
 		return false
 	}
-	if p.Cardinality != other.Cardinality {
-		return false
+	for i, _tgt := range p.SourceKeys {
+		_src5 := other.SourceKeys[i]
+		if _tgt != _src5 {
+			return false
+		}
 	}
 	return true
 }
 
-func (p *CardinalityResponse) String() string {
+func (p *MergeLogCmd) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CardinalityResponse(%+v)", *p)
+	return fmt.Sprintf("MergeLogCmd(%+v)", *p)
 }
 
-type HllService interface {
-	// Parameters:
-	//  - AddLog
-	AddLog(ctx context.Context, addLog *AddLogCmd) (_r Status, _err error)
-	// Parameters:
-	//  - Upd
-	Update(ctx context.Context, upd *UpdateLogCmd) (_r Status, _err error)
-	// Parameters:
-	//  - Mupd
-	UpdateM(ctx context.Context, mupd *UpdateLogMValCmd) (_r Status, _err error)
-	// Parameters:
-	//  - Exp
-	UpdateExpiry(ctx context.Context, exp *UpdateExpiryCmd) (_r Status, _err error)
-	// Parameters:
-	//  - Key
-	DelLog(ctx context.Context, key string) (_r Status, _err error)
-	// Parameters:
-	//  - Key
-	GetCardinality(ctx context.Context, Key string) (_r *CardinalityResponse, _err error)
+// Attributes:
+//   - Key
+//   - Expiry
+type UpdateExpiryCmd struct {
+	Key    string `thrift:"Key,1" db:"Key" json:"Key"`
+	Expiry int64  `thrift:"Expiry,2" db:"Expiry" json:"Expiry"`
 }
 
-type HllServiceClient struct {
-	c    thrift.TClient
-	meta thrift.ResponseMeta
+func NewUpdateExpiryCmd() *UpdateExpiryCmd {
+	return &UpdateExpiryCmd{}
 }
 
-func NewHllServiceClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *HllServiceClient {
-	return &HllServiceClient{
-		c: thrift.NewTStandardClient(f.GetProtocol(t), f.GetProtocol(t)),
-	}
+func (p *UpdateExpiryCmd) GetKey() string {
+	return p.Key
 }
 
-func NewHllServiceClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *HllServiceClient {
-	return &HllServiceClient{
-		c: thrift.NewTStandardClient(iprot, oprot),
-	}
+func (p *UpdateExpiryCmd) GetExpiry() int64 {
+	return p.Expiry
 }
-
-func NewHllServiceClient(c thrift.TClient) *HllServiceClient {
-	return &HllServiceClient{
-		c: c,
+func (p *UpdateExpiryCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-}
-
-func (p *HllServiceClient) Client_() thrift.TClient {
-	return p.c
-}
-
-func (p *HllServiceClient) LastResponseMeta_() thrift.ResponseMeta {
-	return p.meta
-}
 
-func (p *HllServiceClient) SetLastResponseMeta_(meta thrift.ResponseMeta) {
-	p.meta = meta
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
 }
 
-// Parameters:
-//   - AddLog
-func (p *HllServiceClient) AddLog(ctx context.Context, addLog *AddLogCmd) (_r Status, _err error) {
-	var _args2 HllServiceAddLogArgs
-	_args2.AddLog = addLog
-	var _result4 HllServiceAddLogResult
-	var _meta3 thrift.ResponseMeta
-	_meta3, _err = p.Client_().Call(ctx, "AddLog", &_args2, &_result4)
-	p.SetLastResponseMeta_(_meta3)
-	if _err != nil {
-		return
+func (p *UpdateExpiryCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
 	}
-	return _result4.GetSuccess(), nil
+	return nil
 }
 
-// Parameters:
-//   - Upd
-func (p *HllServiceClient) Update(ctx context.Context, upd *UpdateLogCmd) (_r Status, _err error) {
-	var _args5 HllServiceUpdateArgs
-	_args5.Upd = upd
-	var _result7 HllServiceUpdateResult
-	var _meta6 thrift.ResponseMeta
-	_meta6, _err = p.Client_().Call(ctx, "Update", &_args5, &_result7)
-	p.SetLastResponseMeta_(_meta6)
-	if _err != nil {
-		return
+func (p *UpdateExpiryCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Expiry = v
 	}
-	return _result7.GetSuccess(), nil
+	return nil
 }
 
-// Parameters:
-//   - Mupd
-func (p *HllServiceClient) UpdateM(ctx context.Context, mupd *UpdateLogMValCmd) (_r Status, _err error) {
-	var _args8 HllServiceUpdateMArgs
-	_args8.Mupd = mupd
-	var _result10 HllServiceUpdateMResult
-	var _meta9 thrift.ResponseMeta
-	_meta9, _err = p.Client_().Call(ctx, "UpdateM", &_args8, &_result10)
-	p.SetLastResponseMeta_(_meta9)
-	if _err != nil {
-		return
+func (p *UpdateExpiryCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateExpiryCmd"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	return _result10.GetSuccess(), nil
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
 }
 
-// Parameters:
-//   - Exp
-func (p *HllServiceClient) UpdateExpiry(ctx context.Context, exp *UpdateExpiryCmd) (_r Status, _err error) {
-	var _args11 HllServiceUpdateExpiryArgs
-	_args11.Exp = exp
-	var _result13 HllServiceUpdateExpiryResult
-	var _meta12 thrift.ResponseMeta
-	_meta12, _err = p.Client_().Call(ctx, "UpdateExpiry", &_args11, &_result13)
-	p.SetLastResponseMeta_(_meta12)
-	if _err != nil {
-		return
+func (p *UpdateExpiryCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
-	return _result13.GetSuccess(), nil
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
 }
 
-// Parameters:
-//   - Key
-func (p *HllServiceClient) DelLog(ctx context.Context, key string) (_r Status, _err error) {
-	var _args14 HllServiceDelLogArgs
-	_args14.Key = key
-	var _result16 HllServiceDelLogResult
-	var _meta15 thrift.ResponseMeta
-	_meta15, _err = p.Client_().Call(ctx, "DelLog", &_args14, &_result16)
-	p.SetLastResponseMeta_(_meta15)
-	if _err != nil {
-		return
+func (p *UpdateExpiryCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Expiry", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Expiry: ", p), err)
 	}
-	return _result16.GetSuccess(), nil
+	if err := oprot.WriteI64(ctx, int64(p.Expiry)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Expiry (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Expiry: ", p), err)
+	}
+	return err
 }
 
-// Parameters:
-//   - Key
-func (p *HllServiceClient) GetCardinality(ctx context.Context, Key string) (_r *CardinalityResponse, _err error) {
-	var _args17 HllServiceGetCardinalityArgs
-	_args17.Key = Key
-	var _result19 HllServiceGetCardinalityResult
-	var _meta18 thrift.ResponseMeta
-	_meta18, _err = p.Client_().Call(ctx, "GetCardinality", &_args17, &_result19)
-	p.SetLastResponseMeta_(_meta18)
-	if _err != nil {
-		return
+func (p *UpdateExpiryCmd) Equals(other *UpdateExpiryCmd) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
 	}
-	if _ret20 := _result19.GetSuccess(); _ret20 != nil {
-		return _ret20, nil
+	if p.Key != other.Key {
+		return false
 	}
-	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetCardinality failed: unknown result")
+	if p.Expiry != other.Expiry {
+		return false
+	}
+	return true
 }
 
-type HllServiceProcessor struct {
-	processorMap map[string]thrift.TProcessorFunction
-	handler      HllService
+func (p *UpdateExpiryCmd) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UpdateExpiryCmd(%+v)", *p)
 }
 
-func (p *HllServiceProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-	p.processorMap[key] = processor
+// Attributes:
+//   - Key
+//   - Timestamp
+type ExpireAtCmd struct {
+	Key       string `thrift:"Key,1" db:"Key" json:"Key"`
+	Timestamp int64  `thrift:"Timestamp,2" db:"Timestamp" json:"Timestamp"`
 }
 
-func (p *HllServiceProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-	processor, ok = p.processorMap[key]
-	return processor, ok
+func NewExpireAtCmd() *ExpireAtCmd {
+	return &ExpireAtCmd{}
 }
 
-func (p *HllServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-	return p.processorMap
+func (p *ExpireAtCmd) GetKey() string {
+	return p.Key
 }
 
-func NewHllServiceProcessor(handler HllService) *HllServiceProcessor {
-
-	self21 := &HllServiceProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self21.processorMap["AddLog"] = &hllServiceProcessorAddLog{handler: handler}
-	self21.processorMap["Update"] = &hllServiceProcessorUpdate{handler: handler}
-	self21.processorMap["UpdateM"] = &hllServiceProcessorUpdateM{handler: handler}
-	self21.processorMap["UpdateExpiry"] = &hllServiceProcessorUpdateExpiry{handler: handler}
-	self21.processorMap["DelLog"] = &hllServiceProcessorDelLog{handler: handler}
-	self21.processorMap["GetCardinality"] = &hllServiceProcessorGetCardinality{handler: handler}
-	return self21
+func (p *ExpireAtCmd) GetTimestamp() int64 {
+	return p.Timestamp
 }
+func (p *ExpireAtCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
 
-func (p *HllServiceProcessor) Process(ctx context.Context, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	name, _, seqId, err2 := iprot.ReadMessageBegin(ctx)
-	if err2 != nil {
-		return false, thrift.WrapTException(err2)
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
 	}
-	if processor, ok := p.GetProcessorFunction(name); ok {
-		return processor.Process(ctx, seqId, iprot, oprot)
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	iprot.Skip(ctx, thrift.STRUCT)
-	iprot.ReadMessageEnd(ctx)
-	x22 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
-	oprot.WriteMessageBegin(ctx, name, thrift.EXCEPTION, seqId)
-	x22.Write(ctx, oprot)
-	oprot.WriteMessageEnd(ctx)
-	oprot.Flush(ctx)
-	return false, x22
-
+	return nil
 }
 
-type hllServiceProcessorAddLog struct {
-	handler HllService
+func (p *ExpireAtCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
 }
 
-func (p *hllServiceProcessorAddLog) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := HllServiceAddLogArgs{}
-	var err2 error
-	if err2 = args.Read(ctx, iprot); err2 != nil {
-		iprot.ReadMessageEnd(ctx)
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
-		oprot.WriteMessageBegin(ctx, "AddLog", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return false, thrift.WrapTException(err2)
+func (p *ExpireAtCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Timestamp = v
 	}
-	iprot.ReadMessageEnd(ctx)
+	return nil
+}
 
-	tickerCancel := func() {}
-	// Start a goroutine to do server side connectivity check.
-	if thrift.ServerConnectivityCheckInterval > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithCancel(ctx)
-		defer cancel()
-		var tickerCtx context.Context
-		tickerCtx, tickerCancel = context.WithCancel(context.Background())
-		defer tickerCancel()
-		go func(ctx context.Context, cancel context.CancelFunc) {
-			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if !iprot.Transport().IsOpen() {
-						cancel()
-						return
-					}
-				}
-			}
-		}(tickerCtx, cancel)
+func (p *ExpireAtCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ExpireAtCmd"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-
-	result := HllServiceAddLogResult{}
-	var retval Status
-	if retval, err2 = p.handler.AddLog(ctx, args.AddLog); err2 != nil {
-		tickerCancel()
-		if err2 == thrift.ErrAbandonRequest {
-			return false, thrift.WrapTException(err2)
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
 		}
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing AddLog: "+err2.Error())
-		oprot.WriteMessageBegin(ctx, "AddLog", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return true, thrift.WrapTException(err2)
-	} else {
-		result.Success = &retval
 	}
-	tickerCancel()
-	if err2 = oprot.WriteMessageBegin(ctx, "AddLog", thrift.REPLY, seqId); err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *ExpireAtCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
-	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
 	}
-	if err != nil {
-		return
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
 	}
-	return true, err
-}
-
-type hllServiceProcessorUpdate struct {
-	handler HllService
+	return err
 }
 
-func (p *hllServiceProcessorUpdate) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := HllServiceUpdateArgs{}
-	var err2 error
-	if err2 = args.Read(ctx, iprot); err2 != nil {
-		iprot.ReadMessageEnd(ctx)
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
-		oprot.WriteMessageBegin(ctx, "Update", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return false, thrift.WrapTException(err2)
-	}
-	iprot.ReadMessageEnd(ctx)
-
-	tickerCancel := func() {}
-	// Start a goroutine to do server side connectivity check.
-	if thrift.ServerConnectivityCheckInterval > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithCancel(ctx)
-		defer cancel()
-		var tickerCtx context.Context
-		tickerCtx, tickerCancel = context.WithCancel(context.Background())
-		defer tickerCancel()
-		go func(ctx context.Context, cancel context.CancelFunc) {
-			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if !iprot.Transport().IsOpen() {
-						cancel()
-						return
-					}
-				}
-			}
-		}(tickerCtx, cancel)
+func (p *ExpireAtCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Timestamp", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Timestamp: ", p), err)
 	}
-
-	result := HllServiceUpdateResult{}
-	var retval Status
-	if retval, err2 = p.handler.Update(ctx, args.Upd); err2 != nil {
-		tickerCancel()
-		if err2 == thrift.ErrAbandonRequest {
-			return false, thrift.WrapTException(err2)
-		}
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Update: "+err2.Error())
-		oprot.WriteMessageBegin(ctx, "Update", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return true, thrift.WrapTException(err2)
-	} else {
-		result.Success = &retval
+	if err := oprot.WriteI64(ctx, int64(p.Timestamp)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Timestamp (2) field write error: ", p), err)
 	}
-	tickerCancel()
-	if err2 = oprot.WriteMessageBegin(ctx, "Update", thrift.REPLY, seqId); err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Timestamp: ", p), err)
 	}
-	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	return err
+}
+
+func (p *ExpireAtCmd) Equals(other *ExpireAtCmd) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
 	}
-	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if p.Key != other.Key {
+		return false
 	}
-	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if p.Timestamp != other.Timestamp {
+		return false
 	}
-	if err != nil {
-		return
+	return true
+}
+
+func (p *ExpireAtCmd) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return true, err
+	return fmt.Sprintf("ExpireAtCmd(%+v)", *p)
 }
 
-type hllServiceProcessorUpdateM struct {
-	handler HllService
+// Attributes:
+//   - Key
+//   - NewKey
+//   - NoOverwrite
+type RenameLogCmd struct {
+	Key         string `thrift:"Key,1" db:"Key" json:"Key"`
+	NewKey      string `thrift:"NewKey,2" db:"NewKey" json:"NewKey"`
+	NoOverwrite bool   `thrift:"NoOverwrite,3" db:"NoOverwrite" json:"NoOverwrite"`
 }
 
-func (p *hllServiceProcessorUpdateM) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := HllServiceUpdateMArgs{}
-	var err2 error
-	if err2 = args.Read(ctx, iprot); err2 != nil {
-		iprot.ReadMessageEnd(ctx)
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
-		oprot.WriteMessageBegin(ctx, "UpdateM", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return false, thrift.WrapTException(err2)
-	}
-	iprot.ReadMessageEnd(ctx)
+func NewRenameLogCmd() *RenameLogCmd {
+	return &RenameLogCmd{}
+}
+
+func (p *RenameLogCmd) GetKey() string {
+	return p.Key
+}
+
+func (p *RenameLogCmd) GetNewKey() string {
+	return p.NewKey
+}
+
+func (p *RenameLogCmd) GetNoOverwrite() bool {
+	return p.NoOverwrite
+}
+func (p *RenameLogCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.BOOL {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *RenameLogCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *RenameLogCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.NewKey = v
+	}
+	return nil
+}
+
+func (p *RenameLogCmd) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.NoOverwrite = v
+	}
+	return nil
+}
+
+func (p *RenameLogCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "RenameLogCmd"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RenameLogCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *RenameLogCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "NewKey", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NewKey: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.NewKey)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NewKey (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NewKey: ", p), err)
+	}
+	return err
+}
+
+func (p *RenameLogCmd) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "NoOverwrite", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:NoOverwrite: ", p), err)
+	}
+	if err := oprot.WriteBool(ctx, bool(p.NoOverwrite)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NoOverwrite (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:NoOverwrite: ", p), err)
+	}
+	return err
+}
+
+func (p *RenameLogCmd) Equals(other *RenameLogCmd) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.NewKey != other.NewKey {
+		return false
+	}
+	if p.NoOverwrite != other.NoOverwrite {
+		return false
+	}
+	return true
+}
+
+func (p *RenameLogCmd) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RenameLogCmd(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - NewKey
+//   - Replace
+type CopyLogCmd struct {
+	Key     string `thrift:"Key,1" db:"Key" json:"Key"`
+	NewKey  string `thrift:"NewKey,2" db:"NewKey" json:"NewKey"`
+	Replace bool   `thrift:"Replace,3" db:"Replace" json:"Replace"`
+}
+
+func NewCopyLogCmd() *CopyLogCmd {
+	return &CopyLogCmd{}
+}
+
+func (p *CopyLogCmd) GetKey() string {
+	return p.Key
+}
+
+func (p *CopyLogCmd) GetNewKey() string {
+	return p.NewKey
+}
+
+func (p *CopyLogCmd) GetReplace() bool {
+	return p.Replace
+}
+func (p *CopyLogCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.BOOL {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CopyLogCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *CopyLogCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.NewKey = v
+	}
+	return nil
+}
+
+func (p *CopyLogCmd) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Replace = v
+	}
+	return nil
+}
+
+func (p *CopyLogCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "CopyLogCmd"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CopyLogCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *CopyLogCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "NewKey", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NewKey: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.NewKey)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NewKey (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NewKey: ", p), err)
+	}
+	return err
+}
+
+func (p *CopyLogCmd) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Replace", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Replace: ", p), err)
+	}
+	if err := oprot.WriteBool(ctx, bool(p.Replace)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replace (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Replace: ", p), err)
+	}
+	return err
+}
+
+func (p *CopyLogCmd) Equals(other *CopyLogCmd) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.NewKey != other.NewKey {
+		return false
+	}
+	if p.Replace != other.Replace {
+		return false
+	}
+	return true
+}
+
+func (p *CopyLogCmd) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CopyLogCmd(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - Status
+//   - Cardinality
+//   - StdError
+//   - Lower
+//   - Upper
+//   - Estimator
+type CardinalityResponse struct {
+	Key         string  `thrift:"Key,1" db:"Key" json:"Key"`
+	Status      Status  `thrift:"Status,2" db:"Status" json:"Status"`
+	Cardinality int64   `thrift:"Cardinality,3" db:"Cardinality" json:"Cardinality"`
+	StdError    float64 `thrift:"StdError,4" db:"StdError" json:"StdError"`
+	Lower       int64   `thrift:"Lower,5" db:"Lower" json:"Lower"`
+	Upper       int64   `thrift:"Upper,6" db:"Upper" json:"Upper"`
+	Estimator   string  `thrift:"Estimator,7" db:"Estimator" json:"Estimator"`
+}
+
+func NewCardinalityResponse() *CardinalityResponse {
+	return &CardinalityResponse{}
+}
+
+func (p *CardinalityResponse) GetKey() string {
+	return p.Key
+}
+
+func (p *CardinalityResponse) GetStatus() Status {
+	return p.Status
+}
+
+func (p *CardinalityResponse) GetCardinality() int64 {
+	return p.Cardinality
+}
+
+func (p *CardinalityResponse) GetStdError() float64 {
+	return p.StdError
+}
+
+func (p *CardinalityResponse) GetLower() int64 {
+	return p.Lower
+}
+
+func (p *CardinalityResponse) GetUpper() int64 {
+	return p.Upper
+}
+
+func (p *CardinalityResponse) GetEstimator() string {
+	return p.Estimator
+}
+func (p *CardinalityResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 4:
+			if fieldTypeId == thrift.DOUBLE {
+				if err := p.ReadField4(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 5:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField5(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 6:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField6(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 7:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField7(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Cardinality = v
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField4(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(ctx); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.StdError = v
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField5(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Lower = v
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField6(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Upper = v
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) ReadField7(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Estimator = v
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "CardinalityResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CardinalityResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Status: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Status: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Cardinality", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Cardinality: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Cardinality)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Cardinality (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Cardinality: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) writeField4(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "StdError", thrift.DOUBLE, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:StdError: ", p), err)
+	}
+	if err := oprot.WriteDouble(ctx, float64(p.StdError)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.StdError (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:StdError: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) writeField5(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Lower", thrift.I64, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Lower: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Lower)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Lower (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Lower: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) writeField6(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Upper", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:Upper: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Upper)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Upper (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:Upper: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) writeField7(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Estimator", thrift.STRING, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:Estimator: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Estimator)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Estimator (7) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:Estimator: ", p), err)
+	}
+	return err
+}
+
+func (p *CardinalityResponse) Equals(other *CardinalityResponse) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.Status != other.Status {
+		return false
+	}
+	if p.Cardinality != other.Cardinality {
+		return false
+	}
+	if p.StdError != other.StdError {
+		return false
+	}
+	if p.Lower != other.Lower {
+		return false
+	}
+	if p.Upper != other.Upper {
+		return false
+	}
+	if p.Estimator != other.Estimator {
+		return false
+	}
+	return true
+}
+
+func (p *CardinalityResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CardinalityResponse(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - Sketch
+//   - Expiry
+//   - Merge
+type ImportLogCmd struct {
+	Key    string `thrift:"Key,1" db:"Key" json:"Key"`
+	Sketch []byte `thrift:"Sketch,2" db:"Sketch" json:"Sketch"`
+	Expiry int64  `thrift:"Expiry,3" db:"Expiry" json:"Expiry"`
+	Merge  bool   `thrift:"Merge,4" db:"Merge" json:"Merge"`
+}
+
+func NewImportLogCmd() *ImportLogCmd {
+	return &ImportLogCmd{}
+}
+
+func (p *ImportLogCmd) GetKey() string {
+	return p.Key
+}
+
+func (p *ImportLogCmd) GetSketch() []byte {
+	return p.Sketch
+}
+
+func (p *ImportLogCmd) GetExpiry() int64 {
+	return p.Expiry
+}
+
+func (p *ImportLogCmd) GetMerge() bool {
+	return p.Merge
+}
+func (p *ImportLogCmd) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 4:
+			if fieldTypeId == thrift.BOOL {
+				if err := p.ReadField4(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ImportLogCmd) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *ImportLogCmd) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Sketch = v
+	}
+	return nil
+}
+
+func (p *ImportLogCmd) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Expiry = v
+	}
+	return nil
+}
+
+func (p *ImportLogCmd) ReadField4(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(ctx); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Merge = v
+	}
+	return nil
+}
+
+func (p *ImportLogCmd) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ImportLogCmd"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ImportLogCmd) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *ImportLogCmd) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Sketch", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Sketch: ", p), err)
+	}
+	if err := oprot.WriteBinary(ctx, p.Sketch); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sketch (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Sketch: ", p), err)
+	}
+	return err
+}
+
+func (p *ImportLogCmd) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Expiry", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Expiry: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Expiry)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Expiry (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Expiry: ", p), err)
+	}
+	return err
+}
+
+func (p *ImportLogCmd) writeField4(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Merge", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Merge: ", p), err)
+	}
+	if err := oprot.WriteBool(ctx, bool(p.Merge)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Merge (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Merge: ", p), err)
+	}
+	return err
+}
+
+func (p *ImportLogCmd) Equals(other *ImportLogCmd) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if bytes.Compare(p.Sketch, other.Sketch) != 0 {
+		return false
+	}
+	if p.Expiry != other.Expiry {
+		return false
+	}
+	if p.Merge != other.Merge {
+		return false
+	}
+	return true
+}
+
+func (p *ImportLogCmd) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ImportLogCmd(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - Status
+//   - Sketch
+type SketchResponse struct {
+	Key    string `thrift:"Key,1" db:"Key" json:"Key"`
+	Status Status `thrift:"Status,2" db:"Status" json:"Status"`
+	Sketch []byte `thrift:"Sketch,3" db:"Sketch" json:"Sketch"`
+}
+
+func NewSketchResponse() *SketchResponse {
+	return &SketchResponse{}
+}
+
+func (p *SketchResponse) GetKey() string {
+	return p.Key
+}
+
+func (p *SketchResponse) GetStatus() Status {
+	return p.Status
+}
+
+func (p *SketchResponse) GetSketch() []byte {
+	return p.Sketch
+}
+func (p *SketchResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SketchResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *SketchResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *SketchResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Sketch = v
+	}
+	return nil
+}
+
+func (p *SketchResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "SketchResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SketchResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *SketchResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Status: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Status: ", p), err)
+	}
+	return err
+}
+
+func (p *SketchResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Sketch", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Sketch: ", p), err)
+	}
+	if err := oprot.WriteBinary(ctx, p.Sketch); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sketch (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Sketch: ", p), err)
+	}
+	return err
+}
+
+func (p *SketchResponse) Equals(other *SketchResponse) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.Status != other.Status {
+		return false
+	}
+	if bytes.Compare(p.Sketch, other.Sketch) != 0 {
+		return false
+	}
+	return true
+}
+
+func (p *SketchResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SketchResponse(%+v)", *p)
+}
+
+// Attributes:
+//   - Status
+//   - Cardinality
+//   - ErrorBound
+type SetCardinalityResponse struct {
+	Status      Status `thrift:"Status,1" db:"Status" json:"Status"`
+	Cardinality int64  `thrift:"Cardinality,2" db:"Cardinality" json:"Cardinality"`
+	ErrorBound  int64  `thrift:"ErrorBound,3" db:"ErrorBound" json:"ErrorBound"`
+}
+
+func NewSetCardinalityResponse() *SetCardinalityResponse {
+	return &SetCardinalityResponse{}
+}
+
+func (p *SetCardinalityResponse) GetStatus() Status {
+	return p.Status
+}
+
+func (p *SetCardinalityResponse) GetCardinality() int64 {
+	return p.Cardinality
+}
+
+func (p *SetCardinalityResponse) GetErrorBound() int64 {
+	return p.ErrorBound
+}
+func (p *SetCardinalityResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SetCardinalityResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *SetCardinalityResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Cardinality = v
+	}
+	return nil
+}
+
+func (p *SetCardinalityResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.ErrorBound = v
+	}
+	return nil
+}
+
+func (p *SetCardinalityResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "SetCardinalityResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SetCardinalityResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Status: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Status (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Status: ", p), err)
+	}
+	return err
+}
+
+func (p *SetCardinalityResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Cardinality", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Cardinality: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Cardinality)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Cardinality (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Cardinality: ", p), err)
+	}
+	return err
+}
+
+func (p *SetCardinalityResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "ErrorBound", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:ErrorBound: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.ErrorBound)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ErrorBound (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:ErrorBound: ", p), err)
+	}
+	return err
+}
+
+func (p *SetCardinalityResponse) Equals(other *SetCardinalityResponse) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Status != other.Status {
+		return false
+	}
+	if p.Cardinality != other.Cardinality {
+		return false
+	}
+	if p.ErrorBound != other.ErrorBound {
+		return false
+	}
+	return true
+}
+
+func (p *SetCardinalityResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SetCardinalityResponse(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - Status
+//   - TTL
+//   - Expiry
+type TTLResponse struct {
+	Key    string `thrift:"Key,1" db:"Key" json:"Key"`
+	Status Status `thrift:"Status,2" db:"Status" json:"Status"`
+	TTL    int64  `thrift:"TTL,3" db:"TTL" json:"TTL"`
+	Expiry int64  `thrift:"Expiry,4" db:"Expiry" json:"Expiry"`
+}
+
+func NewTTLResponse() *TTLResponse {
+	return &TTLResponse{}
+}
+
+func (p *TTLResponse) GetKey() string {
+	return p.Key
+}
+
+func (p *TTLResponse) GetStatus() Status {
+	return p.Status
+}
+
+func (p *TTLResponse) GetTTL() int64 {
+	return p.TTL
+}
+
+func (p *TTLResponse) GetExpiry() int64 {
+	return p.Expiry
+}
+func (p *TTLResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 4:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField4(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *TTLResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *TTLResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *TTLResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TTL = v
+	}
+	return nil
+}
+
+func (p *TTLResponse) ReadField4(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Expiry = v
+	}
+	return nil
+}
+
+func (p *TTLResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "TTLResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *TTLResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *TTLResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Status: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Status: ", p), err)
+	}
+	return err
+}
+
+func (p *TTLResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "TTL", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:TTL: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.TTL)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.TTL (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:TTL: ", p), err)
+	}
+	return err
+}
+
+func (p *TTLResponse) writeField4(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Expiry", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Expiry: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Expiry)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Expiry (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Expiry: ", p), err)
+	}
+	return err
+}
+
+func (p *TTLResponse) Equals(other *TTLResponse) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.Status != other.Status {
+		return false
+	}
+	if p.TTL != other.TTL {
+		return false
+	}
+	if p.Expiry != other.Expiry {
+		return false
+	}
+	return true
+}
+
+func (p *TTLResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TTLResponse(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - TTL
+type KeyTTL struct {
+	Key string `thrift:"Key,1" db:"Key" json:"Key"`
+	TTL int64  `thrift:"TTL,2" db:"TTL" json:"TTL"`
+}
+
+func NewKeyTTL() *KeyTTL {
+	return &KeyTTL{}
+}
+
+func (p *KeyTTL) GetKey() string {
+	return p.Key
+}
+
+func (p *KeyTTL) GetTTL() int64 {
+	return p.TTL
+}
+func (p *KeyTTL) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *KeyTTL) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *KeyTTL) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.TTL = v
+	}
+	return nil
+}
+
+func (p *KeyTTL) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "KeyTTL"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *KeyTTL) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *KeyTTL) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "TTL", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:TTL: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.TTL)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.TTL (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:TTL: ", p), err)
+	}
+	return err
+}
+
+func (p *KeyTTL) Equals(other *KeyTTL) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.TTL != other.TTL {
+		return false
+	}
+	return true
+}
+
+func (p *KeyTTL) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KeyTTL(%+v)", *p)
+}
+
+// Attributes:
+//   - Status
+//   - Cursor
+//   - Keys
+type ScanResponse struct {
+	Status Status    `thrift:"Status,1" db:"Status" json:"Status"`
+	Cursor string    `thrift:"Cursor,2" db:"Cursor" json:"Cursor"`
+	Keys   []*KeyTTL `thrift:"Keys,3" db:"Keys" json:"Keys"`
+}
+
+func NewScanResponse() *ScanResponse {
+	return &ScanResponse{}
+}
+
+func (p *ScanResponse) GetStatus() Status {
+	return p.Status
+}
+
+func (p *ScanResponse) GetCursor() string {
+	return p.Cursor
+}
+
+func (p *ScanResponse) GetKeys() []*KeyTTL {
+	return p.Keys
+}
+func (p *ScanResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.LIST {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ScanResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *ScanResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Cursor = v
+	}
+	return nil
+}
+
+func (p *ScanResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin(ctx)
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*KeyTTL, 0, size)
+	p.Keys = tSlice
+	for i := 0; i < size; i++ {
+		_elem6 := &KeyTTL{}
+		if err := _elem6.Read(ctx, iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem6), err)
+		}
+		p.Keys = append(p.Keys, _elem6)
+	}
+	if err := iprot.ReadListEnd(ctx); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *ScanResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ScanResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ScanResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Status: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Status (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Status: ", p), err)
+	}
+	return err
+}
+
+func (p *ScanResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Cursor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Cursor: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Cursor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Cursor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Cursor: ", p), err)
+	}
+	return err
+}
+
+func (p *ScanResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Keys", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Keys: ", p), err)
+	}
+	if err := oprot.WriteListBegin(ctx, thrift.STRUCT, len(p.Keys)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Keys {
+		if err := v.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(ctx); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Keys: ", p), err)
+	}
+	return err
+}
+
+func (p *ScanResponse) Equals(other *ScanResponse) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Status != other.Status {
+		return false
+	}
+	if p.Cursor != other.Cursor {
+		return false
+	}
+	if len(p.Keys) != len(other.Keys) {
+		return false
+	}
+	for i, _tgt := range p.Keys {
+		_src7 := other.Keys[i]
+		if !_tgt.Equals(_src7) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ScanResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ScanResponse(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - Status
+//   - Algorithm
+//   - Precision
+//   - Salted
+//   - Expiry
+//   - NonZeroRegisters
+//   - PendingUpdates
+//   - SerializedSize
+//   - Persisted
+//   - CreateTime
+//   - ModifyTime
+type LogInfoResponse struct {
+	Key              string    `thrift:"Key,1" db:"Key" json:"Key"`
+	Status           Status    `thrift:"Status,2" db:"Status" json:"Status"`
+	Algorithm        Algorithm `thrift:"Algorithm,3" db:"Algorithm" json:"Algorithm"`
+	Precision        int32     `thrift:"Precision,4" db:"Precision" json:"Precision"`
+	Salted           bool      `thrift:"Salted,5" db:"Salted" json:"Salted"`
+	Expiry           int64     `thrift:"Expiry,6" db:"Expiry" json:"Expiry"`
+	NonZeroRegisters int64     `thrift:"NonZeroRegisters,7" db:"NonZeroRegisters" json:"NonZeroRegisters"`
+	PendingUpdates   int64     `thrift:"PendingUpdates,8" db:"PendingUpdates" json:"PendingUpdates"`
+	SerializedSize   int64     `thrift:"SerializedSize,9" db:"SerializedSize" json:"SerializedSize"`
+	Persisted        bool      `thrift:"Persisted,10" db:"Persisted" json:"Persisted"`
+	CreateTime       int64     `thrift:"CreateTime,11" db:"CreateTime" json:"CreateTime"`
+	ModifyTime       int64     `thrift:"ModifyTime,12" db:"ModifyTime" json:"ModifyTime"`
+}
+
+func NewLogInfoResponse() *LogInfoResponse {
+	return &LogInfoResponse{}
+}
+
+func (p *LogInfoResponse) GetKey() string {
+	return p.Key
+}
+
+func (p *LogInfoResponse) GetStatus() Status {
+	return p.Status
+}
+
+func (p *LogInfoResponse) GetAlgorithm() Algorithm {
+	return p.Algorithm
+}
+
+func (p *LogInfoResponse) GetPrecision() int32 {
+	return p.Precision
+}
+
+func (p *LogInfoResponse) GetSalted() bool {
+	return p.Salted
+}
+
+func (p *LogInfoResponse) GetExpiry() int64 {
+	return p.Expiry
+}
+
+func (p *LogInfoResponse) GetNonZeroRegisters() int64 {
+	return p.NonZeroRegisters
+}
+
+func (p *LogInfoResponse) GetPendingUpdates() int64 {
+	return p.PendingUpdates
+}
+
+func (p *LogInfoResponse) GetSerializedSize() int64 {
+	return p.SerializedSize
+}
+
+func (p *LogInfoResponse) GetPersisted() bool {
+	return p.Persisted
+}
+
+func (p *LogInfoResponse) GetCreateTime() int64 {
+	return p.CreateTime
+}
+
+func (p *LogInfoResponse) GetModifyTime() int64 {
+	return p.ModifyTime
+}
+func (p *LogInfoResponse) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 4:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField4(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 5:
+			if fieldTypeId == thrift.BOOL {
+				if err := p.ReadField5(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 6:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField6(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 7:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField7(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 8:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField8(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 9:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField9(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 10:
+			if fieldTypeId == thrift.BOOL {
+				if err := p.ReadField10(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 11:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField11(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 12:
+			if fieldTypeId == thrift.I64 {
+				if err := p.ReadField12(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		temp := Algorithm(v)
+		p.Algorithm = temp
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField4(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Precision = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField5(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(ctx); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Salted = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField6(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Expiry = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField7(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.NonZeroRegisters = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField8(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.PendingUpdates = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField9(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.SerializedSize = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField10(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(ctx); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.Persisted = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField11(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.CreateTime = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) ReadField12(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(ctx); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.ModifyTime = v
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "LogInfoResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField8(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField9(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField10(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField11(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField12(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *LogInfoResponse) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Status: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Status: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Algorithm", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Algorithm: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Algorithm)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Algorithm (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Algorithm: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField4(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Precision", thrift.I32, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Precision: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Precision)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Precision (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Precision: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField5(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Salted", thrift.BOOL, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Salted: ", p), err)
+	}
+	if err := oprot.WriteBool(ctx, bool(p.Salted)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Salted (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Salted: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField6(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Expiry", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:Expiry: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.Expiry)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Expiry (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:Expiry: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField7(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "NonZeroRegisters", thrift.I64, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:NonZeroRegisters: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.NonZeroRegisters)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NonZeroRegisters (7) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:NonZeroRegisters: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField8(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "PendingUpdates", thrift.I64, 8); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:PendingUpdates: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.PendingUpdates)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.PendingUpdates (8) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 8:PendingUpdates: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField9(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "SerializedSize", thrift.I64, 9); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:SerializedSize: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.SerializedSize)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.SerializedSize (9) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 9:SerializedSize: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField10(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Persisted", thrift.BOOL, 10); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:Persisted: ", p), err)
+	}
+	if err := oprot.WriteBool(ctx, bool(p.Persisted)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Persisted (10) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 10:Persisted: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField11(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "CreateTime", thrift.I64, 11); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:CreateTime: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.CreateTime)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.CreateTime (11) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 11:CreateTime: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) writeField12(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "ModifyTime", thrift.I64, 12); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:ModifyTime: ", p), err)
+	}
+	if err := oprot.WriteI64(ctx, int64(p.ModifyTime)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ModifyTime (12) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 12:ModifyTime: ", p), err)
+	}
+	return err
+}
+
+func (p *LogInfoResponse) Equals(other *LogInfoResponse) bool {
+	if p == other {
+		return true
+	} else if p == nil || other == nil {
+		return false
+	}
+	if p.Key != other.Key {
+		return false
+	}
+	if p.Status != other.Status {
+		return false
+	}
+	if p.Algorithm != other.Algorithm {
+		return false
+	}
+	if p.Precision != other.Precision {
+		return false
+	}
+	if p.Salted != other.Salted {
+		return false
+	}
+	if p.Expiry != other.Expiry {
+		return false
+	}
+	if p.NonZeroRegisters != other.NonZeroRegisters {
+		return false
+	}
+	if p.PendingUpdates != other.PendingUpdates {
+		return false
+	}
+	if p.SerializedSize != other.SerializedSize {
+		return false
+	}
+	if p.Persisted != other.Persisted {
+		return false
+	}
+	if p.CreateTime != other.CreateTime {
+		return false
+	}
+	if p.ModifyTime != other.ModifyTime {
+		return false
+	}
+	return true
+}
+
+func (p *LogInfoResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LogInfoResponse(%+v)", *p)
+}
+
+type HllService interface {
+	// Parameters:
+	//  - AddLog
+	AddLog(ctx context.Context, addLog *AddLogCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Upd
+	Update(ctx context.Context, upd *UpdateLogCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Mupd
+	UpdateM(ctx context.Context, mupd *UpdateLogMValCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Hupd
+	UpdateHashes(ctx context.Context, hupd *UpdateLogHashesCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Exp
+	UpdateExpiry(ctx context.Context, exp *UpdateExpiryCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Exp
+	ExpireAt(ctx context.Context, exp *ExpireAtCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Key
+	GetTTL(ctx context.Context, Key string) (_r *TTLResponse, _err error)
+	// Parameters:
+	//  - Key
+	Persist(ctx context.Context, Key string) (_r Status, _err error)
+	// Parameters:
+	//  - Key
+	DelLog(ctx context.Context, key string) (_r Status, _err error)
+	// Parameters:
+	//  - Key
+	//  - Estimator
+	GetCardinality(ctx context.Context, Key string, Estimator string) (_r *CardinalityResponse, _err error)
+	// Parameters:
+	//  - Mrg
+	Merge(ctx context.Context, mrg *MergeLogCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Keys
+	//  - Estimator
+	GetUnionCardinality(ctx context.Context, Keys []string, Estimator string) (_r *CardinalityResponse, _err error)
+	// Parameters:
+	//  - Key1
+	//  - Key2
+	GetIntersectionCardinality(ctx context.Context, Key1 string, Key2 string) (_r *SetCardinalityResponse, _err error)
+	// Parameters:
+	//  - Key1
+	//  - Key2
+	GetDifferenceCardinality(ctx context.Context, Key1 string, Key2 string) (_r *SetCardinalityResponse, _err error)
+	// Parameters:
+	//  - Key
+	ExportLog(ctx context.Context, Key string) (_r *SketchResponse, _err error)
+	// Parameters:
+	//  - Imp
+	ImportLog(ctx context.Context, imp *ImportLogCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Cursor
+	//  - Match
+	//  - Count
+	Scan(ctx context.Context, Cursor string, Match string, Count int32) (_r *ScanResponse, _err error)
+	// Parameters:
+	//  - Key
+	GetLogInfo(ctx context.Context, Key string) (_r *LogInfoResponse, _err error)
+	// Parameters:
+	//  - Ren
+	Rename(ctx context.Context, ren *RenameLogCmd) (_r Status, _err error)
+	// Parameters:
+	//  - Cpy
+	Copy(ctx context.Context, cpy *CopyLogCmd) (_r Status, _err error)
+}
+
+type HllServiceClient struct {
+	c    thrift.TClient
+	meta thrift.ResponseMeta
+}
+
+func NewHllServiceClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *HllServiceClient {
+	return &HllServiceClient{
+		c: thrift.NewTStandardClient(f.GetProtocol(t), f.GetProtocol(t)),
+	}
+}
+
+func NewHllServiceClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *HllServiceClient {
+	return &HllServiceClient{
+		c: thrift.NewTStandardClient(iprot, oprot),
+	}
+}
+
+func NewHllServiceClient(c thrift.TClient) *HllServiceClient {
+	return &HllServiceClient{
+		c: c,
+	}
+}
+
+func (p *HllServiceClient) Client_() thrift.TClient {
+	return p.c
+}
+
+func (p *HllServiceClient) LastResponseMeta_() thrift.ResponseMeta {
+	return p.meta
+}
+
+func (p *HllServiceClient) SetLastResponseMeta_(meta thrift.ResponseMeta) {
+	p.meta = meta
+}
+
+// Parameters:
+//   - AddLog
+func (p *HllServiceClient) AddLog(ctx context.Context, addLog *AddLogCmd) (_r Status, _err error) {
+	var _args8 HllServiceAddLogArgs
+	_args8.AddLog = addLog
+	var _result10 HllServiceAddLogResult
+	var _meta9 thrift.ResponseMeta
+	_meta9, _err = p.Client_().Call(ctx, "AddLog", &_args8, &_result10)
+	p.SetLastResponseMeta_(_meta9)
+	if _err != nil {
+		return
+	}
+	return _result10.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Upd
+func (p *HllServiceClient) Update(ctx context.Context, upd *UpdateLogCmd) (_r Status, _err error) {
+	var _args11 HllServiceUpdateArgs
+	_args11.Upd = upd
+	var _result13 HllServiceUpdateResult
+	var _meta12 thrift.ResponseMeta
+	_meta12, _err = p.Client_().Call(ctx, "Update", &_args11, &_result13)
+	p.SetLastResponseMeta_(_meta12)
+	if _err != nil {
+		return
+	}
+	return _result13.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Mupd
+func (p *HllServiceClient) UpdateM(ctx context.Context, mupd *UpdateLogMValCmd) (_r Status, _err error) {
+	var _args14 HllServiceUpdateMArgs
+	_args14.Mupd = mupd
+	var _result16 HllServiceUpdateMResult
+	var _meta15 thrift.ResponseMeta
+	_meta15, _err = p.Client_().Call(ctx, "UpdateM", &_args14, &_result16)
+	p.SetLastResponseMeta_(_meta15)
+	if _err != nil {
+		return
+	}
+	return _result16.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Hupd
+func (p *HllServiceClient) UpdateHashes(ctx context.Context, hupd *UpdateLogHashesCmd) (_r Status, _err error) {
+	var _args17 HllServiceUpdateHashesArgs
+	_args17.Hupd = hupd
+	var _result19 HllServiceUpdateHashesResult
+	var _meta18 thrift.ResponseMeta
+	_meta18, _err = p.Client_().Call(ctx, "UpdateHashes", &_args17, &_result19)
+	p.SetLastResponseMeta_(_meta18)
+	if _err != nil {
+		return
+	}
+	return _result19.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Exp
+func (p *HllServiceClient) UpdateExpiry(ctx context.Context, exp *UpdateExpiryCmd) (_r Status, _err error) {
+	var _args20 HllServiceUpdateExpiryArgs
+	_args20.Exp = exp
+	var _result22 HllServiceUpdateExpiryResult
+	var _meta21 thrift.ResponseMeta
+	_meta21, _err = p.Client_().Call(ctx, "UpdateExpiry", &_args20, &_result22)
+	p.SetLastResponseMeta_(_meta21)
+	if _err != nil {
+		return
+	}
+	return _result22.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Exp
+func (p *HllServiceClient) ExpireAt(ctx context.Context, exp *ExpireAtCmd) (_r Status, _err error) {
+	var _args23 HllServiceExpireAtArgs
+	_args23.Exp = exp
+	var _result25 HllServiceExpireAtResult
+	var _meta24 thrift.ResponseMeta
+	_meta24, _err = p.Client_().Call(ctx, "ExpireAt", &_args23, &_result25)
+	p.SetLastResponseMeta_(_meta24)
+	if _err != nil {
+		return
+	}
+	return _result25.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Key
+func (p *HllServiceClient) GetTTL(ctx context.Context, Key string) (_r *TTLResponse, _err error) {
+	var _args26 HllServiceGetTTLArgs
+	_args26.Key = Key
+	var _result28 HllServiceGetTTLResult
+	var _meta27 thrift.ResponseMeta
+	_meta27, _err = p.Client_().Call(ctx, "GetTTL", &_args26, &_result28)
+	p.SetLastResponseMeta_(_meta27)
+	if _err != nil {
+		return
+	}
+	if _ret29 := _result28.GetSuccess(); _ret29 != nil {
+		return _ret29, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetTTL failed: unknown result")
+}
+
+// Parameters:
+//   - Key
+func (p *HllServiceClient) Persist(ctx context.Context, Key string) (_r Status, _err error) {
+	var _args30 HllServicePersistArgs
+	_args30.Key = Key
+	var _result32 HllServicePersistResult
+	var _meta31 thrift.ResponseMeta
+	_meta31, _err = p.Client_().Call(ctx, "Persist", &_args30, &_result32)
+	p.SetLastResponseMeta_(_meta31)
+	if _err != nil {
+		return
+	}
+	return _result32.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Key
+func (p *HllServiceClient) DelLog(ctx context.Context, key string) (_r Status, _err error) {
+	var _args33 HllServiceDelLogArgs
+	_args33.Key = key
+	var _result35 HllServiceDelLogResult
+	var _meta34 thrift.ResponseMeta
+	_meta34, _err = p.Client_().Call(ctx, "DelLog", &_args33, &_result35)
+	p.SetLastResponseMeta_(_meta34)
+	if _err != nil {
+		return
+	}
+	return _result35.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Key
+//   - Estimator
+func (p *HllServiceClient) GetCardinality(ctx context.Context, Key string, Estimator string) (_r *CardinalityResponse, _err error) {
+	var _args36 HllServiceGetCardinalityArgs
+	_args36.Key = Key
+	_args36.Estimator = Estimator
+	var _result38 HllServiceGetCardinalityResult
+	var _meta37 thrift.ResponseMeta
+	_meta37, _err = p.Client_().Call(ctx, "GetCardinality", &_args36, &_result38)
+	p.SetLastResponseMeta_(_meta37)
+	if _err != nil {
+		return
+	}
+	if _ret39 := _result38.GetSuccess(); _ret39 != nil {
+		return _ret39, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetCardinality failed: unknown result")
+}
+
+// Parameters:
+//   - Mrg
+func (p *HllServiceClient) Merge(ctx context.Context, mrg *MergeLogCmd) (_r Status, _err error) {
+	var _args40 HllServiceMergeArgs
+	_args40.Mrg = mrg
+	var _result42 HllServiceMergeResult
+	var _meta41 thrift.ResponseMeta
+	_meta41, _err = p.Client_().Call(ctx, "Merge", &_args40, &_result42)
+	p.SetLastResponseMeta_(_meta41)
+	if _err != nil {
+		return
+	}
+	return _result42.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Keys
+//   - Estimator
+func (p *HllServiceClient) GetUnionCardinality(ctx context.Context, Keys []string, Estimator string) (_r *CardinalityResponse, _err error) {
+	var _args43 HllServiceGetUnionCardinalityArgs
+	_args43.Keys = Keys
+	_args43.Estimator = Estimator
+	var _result45 HllServiceGetUnionCardinalityResult
+	var _meta44 thrift.ResponseMeta
+	_meta44, _err = p.Client_().Call(ctx, "GetUnionCardinality", &_args43, &_result45)
+	p.SetLastResponseMeta_(_meta44)
+	if _err != nil {
+		return
+	}
+	if _ret46 := _result45.GetSuccess(); _ret46 != nil {
+		return _ret46, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetUnionCardinality failed: unknown result")
+}
+
+// Parameters:
+//   - Key1
+//   - Key2
+func (p *HllServiceClient) GetIntersectionCardinality(ctx context.Context, Key1 string, Key2 string) (_r *SetCardinalityResponse, _err error) {
+	var _args47 HllServiceGetIntersectionCardinalityArgs
+	_args47.Key1 = Key1
+	_args47.Key2 = Key2
+	var _result49 HllServiceGetIntersectionCardinalityResult
+	var _meta48 thrift.ResponseMeta
+	_meta48, _err = p.Client_().Call(ctx, "GetIntersectionCardinality", &_args47, &_result49)
+	p.SetLastResponseMeta_(_meta48)
+	if _err != nil {
+		return
+	}
+	if _ret50 := _result49.GetSuccess(); _ret50 != nil {
+		return _ret50, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetIntersectionCardinality failed: unknown result")
+}
+
+// Parameters:
+//   - Key1
+//   - Key2
+func (p *HllServiceClient) GetDifferenceCardinality(ctx context.Context, Key1 string, Key2 string) (_r *SetCardinalityResponse, _err error) {
+	var _args51 HllServiceGetDifferenceCardinalityArgs
+	_args51.Key1 = Key1
+	_args51.Key2 = Key2
+	var _result53 HllServiceGetDifferenceCardinalityResult
+	var _meta52 thrift.ResponseMeta
+	_meta52, _err = p.Client_().Call(ctx, "GetDifferenceCardinality", &_args51, &_result53)
+	p.SetLastResponseMeta_(_meta52)
+	if _err != nil {
+		return
+	}
+	if _ret54 := _result53.GetSuccess(); _ret54 != nil {
+		return _ret54, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetDifferenceCardinality failed: unknown result")
+}
+
+// Parameters:
+//   - Key
+func (p *HllServiceClient) ExportLog(ctx context.Context, Key string) (_r *SketchResponse, _err error) {
+	var _args55 HllServiceExportLogArgs
+	_args55.Key = Key
+	var _result57 HllServiceExportLogResult
+	var _meta56 thrift.ResponseMeta
+	_meta56, _err = p.Client_().Call(ctx, "ExportLog", &_args55, &_result57)
+	p.SetLastResponseMeta_(_meta56)
+	if _err != nil {
+		return
+	}
+	if _ret58 := _result57.GetSuccess(); _ret58 != nil {
+		return _ret58, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "ExportLog failed: unknown result")
+}
+
+// Parameters:
+//   - Imp
+func (p *HllServiceClient) ImportLog(ctx context.Context, imp *ImportLogCmd) (_r Status, _err error) {
+	var _args59 HllServiceImportLogArgs
+	_args59.Imp = imp
+	var _result61 HllServiceImportLogResult
+	var _meta60 thrift.ResponseMeta
+	_meta60, _err = p.Client_().Call(ctx, "ImportLog", &_args59, &_result61)
+	p.SetLastResponseMeta_(_meta60)
+	if _err != nil {
+		return
+	}
+	return _result61.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Cursor
+//   - Match
+//   - Count
+func (p *HllServiceClient) Scan(ctx context.Context, Cursor string, Match string, Count int32) (_r *ScanResponse, _err error) {
+	var _args62 HllServiceScanArgs
+	_args62.Cursor = Cursor
+	_args62.Match = Match
+	_args62.Count = Count
+	var _result64 HllServiceScanResult
+	var _meta63 thrift.ResponseMeta
+	_meta63, _err = p.Client_().Call(ctx, "Scan", &_args62, &_result64)
+	p.SetLastResponseMeta_(_meta63)
+	if _err != nil {
+		return
+	}
+	if _ret65 := _result64.GetSuccess(); _ret65 != nil {
+		return _ret65, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "Scan failed: unknown result")
+}
+
+// Parameters:
+//   - Key
+func (p *HllServiceClient) GetLogInfo(ctx context.Context, Key string) (_r *LogInfoResponse, _err error) {
+	var _args66 HllServiceGetLogInfoArgs
+	_args66.Key = Key
+	var _result68 HllServiceGetLogInfoResult
+	var _meta67 thrift.ResponseMeta
+	_meta67, _err = p.Client_().Call(ctx, "GetLogInfo", &_args66, &_result68)
+	p.SetLastResponseMeta_(_meta67)
+	if _err != nil {
+		return
+	}
+	if _ret69 := _result68.GetSuccess(); _ret69 != nil {
+		return _ret69, nil
+	}
+	return nil, thrift.NewTApplicationException(thrift.MISSING_RESULT, "GetLogInfo failed: unknown result")
+}
+
+// Parameters:
+//   - Ren
+func (p *HllServiceClient) Rename(ctx context.Context, ren *RenameLogCmd) (_r Status, _err error) {
+	var _args70 HllServiceRenameArgs
+	_args70.Ren = ren
+	var _result72 HllServiceRenameResult
+	var _meta71 thrift.ResponseMeta
+	_meta71, _err = p.Client_().Call(ctx, "Rename", &_args70, &_result72)
+	p.SetLastResponseMeta_(_meta71)
+	if _err != nil {
+		return
+	}
+	return _result72.GetSuccess(), nil
+}
+
+// Parameters:
+//   - Cpy
+func (p *HllServiceClient) Copy(ctx context.Context, cpy *CopyLogCmd) (_r Status, _err error) {
+	var _args73 HllServiceCopyArgs
+	_args73.Cpy = cpy
+	var _result75 HllServiceCopyResult
+	var _meta74 thrift.ResponseMeta
+	_meta74, _err = p.Client_().Call(ctx, "Copy", &_args73, &_result75)
+	p.SetLastResponseMeta_(_meta74)
+	if _err != nil {
+		return
+	}
+	return _result75.GetSuccess(), nil
+}
+
+type HllServiceProcessor struct {
+	processorMap map[string]thrift.TProcessorFunction
+	handler      HllService
+}
+
+func (p *HllServiceProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+	p.processorMap[key] = processor
+}
+
+func (p *HllServiceProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+	processor, ok = p.processorMap[key]
+	return processor, ok
+}
+
+func (p *HllServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+	return p.processorMap
+}
+
+func NewHllServiceProcessor(handler HllService) *HllServiceProcessor {
+
+	self76 := &HllServiceProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self76.processorMap["AddLog"] = &hllServiceProcessorAddLog{handler: handler}
+	self76.processorMap["Update"] = &hllServiceProcessorUpdate{handler: handler}
+	self76.processorMap["UpdateM"] = &hllServiceProcessorUpdateM{handler: handler}
+	self76.processorMap["UpdateHashes"] = &hllServiceProcessorUpdateHashes{handler: handler}
+	self76.processorMap["UpdateExpiry"] = &hllServiceProcessorUpdateExpiry{handler: handler}
+	self76.processorMap["ExpireAt"] = &hllServiceProcessorExpireAt{handler: handler}
+	self76.processorMap["GetTTL"] = &hllServiceProcessorGetTTL{handler: handler}
+	self76.processorMap["Persist"] = &hllServiceProcessorPersist{handler: handler}
+	self76.processorMap["DelLog"] = &hllServiceProcessorDelLog{handler: handler}
+	self76.processorMap["GetCardinality"] = &hllServiceProcessorGetCardinality{handler: handler}
+	self76.processorMap["Merge"] = &hllServiceProcessorMerge{handler: handler}
+	self76.processorMap["GetUnionCardinality"] = &hllServiceProcessorGetUnionCardinality{handler: handler}
+	self76.processorMap["GetIntersectionCardinality"] = &hllServiceProcessorGetIntersectionCardinality{handler: handler}
+	self76.processorMap["GetDifferenceCardinality"] = &hllServiceProcessorGetDifferenceCardinality{handler: handler}
+	self76.processorMap["ExportLog"] = &hllServiceProcessorExportLog{handler: handler}
+	self76.processorMap["ImportLog"] = &hllServiceProcessorImportLog{handler: handler}
+	self76.processorMap["Scan"] = &hllServiceProcessorScan{handler: handler}
+	self76.processorMap["GetLogInfo"] = &hllServiceProcessorGetLogInfo{handler: handler}
+	self76.processorMap["Rename"] = &hllServiceProcessorRename{handler: handler}
+	self76.processorMap["Copy"] = &hllServiceProcessorCopy{handler: handler}
+	return self76
+}
+
+func (p *HllServiceProcessor) Process(ctx context.Context, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	name, _, seqId, err2 := iprot.ReadMessageBegin(ctx)
+	if err2 != nil {
+		return false, thrift.WrapTException(err2)
+	}
+	if processor, ok := p.GetProcessorFunction(name); ok {
+		return processor.Process(ctx, seqId, iprot, oprot)
+	}
+	iprot.Skip(ctx, thrift.STRUCT)
+	iprot.ReadMessageEnd(ctx)
+	x77 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	oprot.WriteMessageBegin(ctx, name, thrift.EXCEPTION, seqId)
+	x77.Write(ctx, oprot)
+	oprot.WriteMessageEnd(ctx)
+	oprot.Flush(ctx)
+	return false, x77
+
+}
+
+type hllServiceProcessorAddLog struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorAddLog) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceAddLogArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "AddLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceAddLogResult{}
+	var retval Status
+	if retval, err2 = p.handler.AddLog(ctx, args.AddLog); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing AddLog: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "AddLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "AddLog", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorUpdate struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorUpdate) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceUpdateArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "Update", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceUpdateResult{}
+	var retval Status
+	if retval, err2 = p.handler.Update(ctx, args.Upd); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Update: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "Update", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "Update", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorUpdateM struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorUpdateM) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceUpdateMArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "UpdateM", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceUpdateMResult{}
+	var retval Status
+	if retval, err2 = p.handler.UpdateM(ctx, args.Mupd); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateM: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "UpdateM", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "UpdateM", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorUpdateHashes struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorUpdateHashes) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceUpdateHashesArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "UpdateHashes", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceUpdateHashesResult{}
+	var retval Status
+	if retval, err2 = p.handler.UpdateHashes(ctx, args.Hupd); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateHashes: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "UpdateHashes", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "UpdateHashes", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorUpdateExpiry struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorUpdateExpiry) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceUpdateExpiryArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "UpdateExpiry", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceUpdateExpiryResult{}
+	var retval Status
+	if retval, err2 = p.handler.UpdateExpiry(ctx, args.Exp); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateExpiry: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "UpdateExpiry", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "UpdateExpiry", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorExpireAt struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorExpireAt) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceExpireAtArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "ExpireAt", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceExpireAtResult{}
+	var retval Status
+	if retval, err2 = p.handler.ExpireAt(ctx, args.Exp); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ExpireAt: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "ExpireAt", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "ExpireAt", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorGetTTL struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorGetTTL) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceGetTTLArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetTTL", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceGetTTLResult{}
+	var retval *TTLResponse
+	if retval, err2 = p.handler.GetTTL(ctx, args.Key); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetTTL: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetTTL", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "GetTTL", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorPersist struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorPersist) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServicePersistArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "Persist", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServicePersistResult{}
+	var retval Status
+	if retval, err2 = p.handler.Persist(ctx, args.Key); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Persist: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "Persist", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "Persist", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorDelLog struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorDelLog) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceDelLogArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "DelLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceDelLogResult{}
+	var retval Status
+	if retval, err2 = p.handler.DelLog(ctx, args.Key); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DelLog: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "DelLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "DelLog", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorGetCardinality struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorGetCardinality) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceGetCardinalityArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceGetCardinalityResult{}
+	var retval *CardinalityResponse
+	if retval, err2 = p.handler.GetCardinality(ctx, args.Key, args.Estimator); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetCardinality: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "GetCardinality", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorMerge struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorMerge) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceMergeArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "Merge", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceMergeResult{}
+	var retval Status
+	if retval, err2 = p.handler.Merge(ctx, args.Mrg); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Merge: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "Merge", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "Merge", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorGetUnionCardinality struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorGetUnionCardinality) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceGetUnionCardinalityArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetUnionCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceGetUnionCardinalityResult{}
+	var retval *CardinalityResponse
+	if retval, err2 = p.handler.GetUnionCardinality(ctx, args.Keys, args.Estimator); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetUnionCardinality: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetUnionCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "GetUnionCardinality", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorGetIntersectionCardinality struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorGetIntersectionCardinality) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceGetIntersectionCardinalityArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetIntersectionCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceGetIntersectionCardinalityResult{}
+	var retval *SetCardinalityResponse
+	if retval, err2 = p.handler.GetIntersectionCardinality(ctx, args.Key1, args.Key2); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetIntersectionCardinality: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetIntersectionCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "GetIntersectionCardinality", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorGetDifferenceCardinality struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorGetDifferenceCardinality) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceGetDifferenceCardinalityArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetDifferenceCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceGetDifferenceCardinalityResult{}
+	var retval *SetCardinalityResponse
+	if retval, err2 = p.handler.GetDifferenceCardinality(ctx, args.Key1, args.Key2); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetDifferenceCardinality: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetDifferenceCardinality", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "GetDifferenceCardinality", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorExportLog struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorExportLog) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceExportLogArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "ExportLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceExportLogResult{}
+	var retval *SketchResponse
+	if retval, err2 = p.handler.ExportLog(ctx, args.Key); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ExportLog: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "ExportLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "ExportLog", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorImportLog struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorImportLog) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceImportLogArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "ImportLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceImportLogResult{}
+	var retval Status
+	if retval, err2 = p.handler.ImportLog(ctx, args.Imp); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ImportLog: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "ImportLog", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "ImportLog", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorScan struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorScan) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceScanArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "Scan", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceScanResult{}
+	var retval *ScanResponse
+	if retval, err2 = p.handler.Scan(ctx, args.Cursor, args.Match, args.Count); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Scan: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "Scan", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "Scan", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorGetLogInfo struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorGetLogInfo) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceGetLogInfoArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetLogInfo", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceGetLogInfoResult{}
+	var retval *LogInfoResponse
+	if retval, err2 = p.handler.GetLogInfo(ctx, args.Key); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetLogInfo: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "GetLogInfo", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "GetLogInfo", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorRename struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorRename) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceRenameArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "Rename", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceRenameResult{}
+	var retval Status
+	if retval, err2 = p.handler.Rename(ctx, args.Ren); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Rename: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "Rename", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "Rename", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type hllServiceProcessorCopy struct {
+	handler HllService
+}
+
+func (p *hllServiceProcessorCopy) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := HllServiceCopyArgs{}
+	var err2 error
+	if err2 = args.Read(ctx, iprot); err2 != nil {
+		iprot.ReadMessageEnd(ctx)
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
+		oprot.WriteMessageBegin(ctx, "Copy", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return false, thrift.WrapTException(err2)
+	}
+	iprot.ReadMessageEnd(ctx)
+
+	tickerCancel := func() {}
+	// Start a goroutine to do server side connectivity check.
+	if thrift.ServerConnectivityCheckInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		var tickerCtx context.Context
+		tickerCtx, tickerCancel = context.WithCancel(context.Background())
+		defer tickerCancel()
+		go func(ctx context.Context, cancel context.CancelFunc) {
+			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if !iprot.Transport().IsOpen() {
+						cancel()
+						return
+					}
+				}
+			}
+		}(tickerCtx, cancel)
+	}
+
+	result := HllServiceCopyResult{}
+	var retval Status
+	if retval, err2 = p.handler.Copy(ctx, args.Cpy); err2 != nil {
+		tickerCancel()
+		if err2 == thrift.ErrAbandonRequest {
+			return false, thrift.WrapTException(err2)
+		}
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Copy: "+err2.Error())
+		oprot.WriteMessageBegin(ctx, "Copy", thrift.EXCEPTION, seqId)
+		x.Write(ctx, oprot)
+		oprot.WriteMessageEnd(ctx)
+		oprot.Flush(ctx)
+		return true, thrift.WrapTException(err2)
+	} else {
+		result.Success = &retval
+	}
+	tickerCancel()
+	if err2 = oprot.WriteMessageBegin(ctx, "Copy", thrift.REPLY, seqId); err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+		err = thrift.WrapTException(err2)
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//   - AddLog
+type HllServiceAddLogArgs struct {
+	AddLog *AddLogCmd `thrift:"addLog,1" db:"addLog" json:"addLog"`
+}
+
+func NewHllServiceAddLogArgs() *HllServiceAddLogArgs {
+	return &HllServiceAddLogArgs{}
+}
+
+var HllServiceAddLogArgs_AddLog_DEFAULT *AddLogCmd
+
+func (p *HllServiceAddLogArgs) GetAddLog() *AddLogCmd {
+	if !p.IsSetAddLog() {
+		return HllServiceAddLogArgs_AddLog_DEFAULT
+	}
+	return p.AddLog
+}
+func (p *HllServiceAddLogArgs) IsSetAddLog() bool {
+	return p.AddLog != nil
+}
+
+func (p *HllServiceAddLogArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceAddLogArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.AddLog = &AddLogCmd{}
+	if err := p.AddLog.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.AddLog), err)
+	}
+	return nil
+}
+
+func (p *HllServiceAddLogArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "AddLog_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceAddLogArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "addLog", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:addLog: ", p), err)
+	}
+	if err := p.AddLog.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.AddLog), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:addLog: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceAddLogArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceAddLogArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceAddLogResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceAddLogResult() *HllServiceAddLogResult {
+	return &HllServiceAddLogResult{}
+}
+
+var HllServiceAddLogResult_Success_DEFAULT Status
+
+func (p *HllServiceAddLogResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceAddLogResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceAddLogResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceAddLogResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceAddLogResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceAddLogResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "AddLog_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceAddLogResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceAddLogResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceAddLogResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Upd
+type HllServiceUpdateArgs struct {
+	Upd *UpdateLogCmd `thrift:"upd,1" db:"upd" json:"upd"`
+}
+
+func NewHllServiceUpdateArgs() *HllServiceUpdateArgs {
+	return &HllServiceUpdateArgs{}
+}
+
+var HllServiceUpdateArgs_Upd_DEFAULT *UpdateLogCmd
+
+func (p *HllServiceUpdateArgs) GetUpd() *UpdateLogCmd {
+	if !p.IsSetUpd() {
+		return HllServiceUpdateArgs_Upd_DEFAULT
+	}
+	return p.Upd
+}
+func (p *HllServiceUpdateArgs) IsSetUpd() bool {
+	return p.Upd != nil
+}
+
+func (p *HllServiceUpdateArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Upd = &UpdateLogCmd{}
+	if err := p.Upd.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Upd), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Update_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "upd", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:upd: ", p), err)
+	}
+	if err := p.Upd.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Upd), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:upd: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceUpdateArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceUpdateResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceUpdateResult() *HllServiceUpdateResult {
+	return &HllServiceUpdateResult{}
+}
+
+var HllServiceUpdateResult_Success_DEFAULT Status
+
+func (p *HllServiceUpdateResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceUpdateResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceUpdateResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceUpdateResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Update_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceUpdateResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Mupd
+type HllServiceUpdateMArgs struct {
+	Mupd *UpdateLogMValCmd `thrift:"mupd,1" db:"mupd" json:"mupd"`
+}
+
+func NewHllServiceUpdateMArgs() *HllServiceUpdateMArgs {
+	return &HllServiceUpdateMArgs{}
+}
+
+var HllServiceUpdateMArgs_Mupd_DEFAULT *UpdateLogMValCmd
+
+func (p *HllServiceUpdateMArgs) GetMupd() *UpdateLogMValCmd {
+	if !p.IsSetMupd() {
+		return HllServiceUpdateMArgs_Mupd_DEFAULT
+	}
+	return p.Mupd
+}
+func (p *HllServiceUpdateMArgs) IsSetMupd() bool {
+	return p.Mupd != nil
+}
+
+func (p *HllServiceUpdateMArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateMArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Mupd = &UpdateLogMValCmd{}
+	if err := p.Mupd.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Mupd), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateMArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateM_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateMArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "mupd", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:mupd: ", p), err)
+	}
+	if err := p.Mupd.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Mupd), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:mupd: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceUpdateMArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateMArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceUpdateMResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceUpdateMResult() *HllServiceUpdateMResult {
+	return &HllServiceUpdateMResult{}
+}
+
+var HllServiceUpdateMResult_Success_DEFAULT Status
+
+func (p *HllServiceUpdateMResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceUpdateMResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceUpdateMResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceUpdateMResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateMResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateMResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateM_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateMResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceUpdateMResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateMResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Hupd
+type HllServiceUpdateHashesArgs struct {
+	Hupd *UpdateLogHashesCmd `thrift:"hupd,1" db:"hupd" json:"hupd"`
+}
+
+func NewHllServiceUpdateHashesArgs() *HllServiceUpdateHashesArgs {
+	return &HllServiceUpdateHashesArgs{}
+}
+
+var HllServiceUpdateHashesArgs_Hupd_DEFAULT *UpdateLogHashesCmd
+
+func (p *HllServiceUpdateHashesArgs) GetHupd() *UpdateLogHashesCmd {
+	if !p.IsSetHupd() {
+		return HllServiceUpdateHashesArgs_Hupd_DEFAULT
+	}
+	return p.Hupd
+}
+func (p *HllServiceUpdateHashesArgs) IsSetHupd() bool {
+	return p.Hupd != nil
+}
+
+func (p *HllServiceUpdateHashesArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateHashesArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Hupd = &UpdateLogHashesCmd{}
+	if err := p.Hupd.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Hupd), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateHashesArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateHashes_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateHashesArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "hupd", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:hupd: ", p), err)
+	}
+	if err := p.Hupd.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Hupd), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:hupd: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceUpdateHashesArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateHashesArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceUpdateHashesResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceUpdateHashesResult() *HllServiceUpdateHashesResult {
+	return &HllServiceUpdateHashesResult{}
+}
+
+var HllServiceUpdateHashesResult_Success_DEFAULT Status
+
+func (p *HllServiceUpdateHashesResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceUpdateHashesResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceUpdateHashesResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceUpdateHashesResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateHashesResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateHashesResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateHashes_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateHashesResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceUpdateHashesResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateHashesResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Exp
+type HllServiceUpdateExpiryArgs struct {
+	Exp *UpdateExpiryCmd `thrift:"exp,1" db:"exp" json:"exp"`
+}
+
+func NewHllServiceUpdateExpiryArgs() *HllServiceUpdateExpiryArgs {
+	return &HllServiceUpdateExpiryArgs{}
+}
+
+var HllServiceUpdateExpiryArgs_Exp_DEFAULT *UpdateExpiryCmd
+
+func (p *HllServiceUpdateExpiryArgs) GetExp() *UpdateExpiryCmd {
+	if !p.IsSetExp() {
+		return HllServiceUpdateExpiryArgs_Exp_DEFAULT
+	}
+	return p.Exp
+}
+func (p *HllServiceUpdateExpiryArgs) IsSetExp() bool {
+	return p.Exp != nil
+}
+
+func (p *HllServiceUpdateExpiryArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateExpiryArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Exp = &UpdateExpiryCmd{}
+	if err := p.Exp.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Exp), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateExpiryArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateExpiry_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateExpiryArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "exp", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:exp: ", p), err)
+	}
+	if err := p.Exp.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Exp), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:exp: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceUpdateExpiryArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateExpiryArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceUpdateExpiryResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceUpdateExpiryResult() *HllServiceUpdateExpiryResult {
+	return &HllServiceUpdateExpiryResult{}
+}
+
+var HllServiceUpdateExpiryResult_Success_DEFAULT Status
+
+func (p *HllServiceUpdateExpiryResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceUpdateExpiryResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceUpdateExpiryResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceUpdateExpiryResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateExpiryResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateExpiryResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "UpdateExpiry_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceUpdateExpiryResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceUpdateExpiryResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceUpdateExpiryResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Exp
+type HllServiceExpireAtArgs struct {
+	Exp *ExpireAtCmd `thrift:"exp,1" db:"exp" json:"exp"`
+}
+
+func NewHllServiceExpireAtArgs() *HllServiceExpireAtArgs {
+	return &HllServiceExpireAtArgs{}
+}
+
+var HllServiceExpireAtArgs_Exp_DEFAULT *ExpireAtCmd
+
+func (p *HllServiceExpireAtArgs) GetExp() *ExpireAtCmd {
+	if !p.IsSetExp() {
+		return HllServiceExpireAtArgs_Exp_DEFAULT
+	}
+	return p.Exp
+}
+func (p *HllServiceExpireAtArgs) IsSetExp() bool {
+	return p.Exp != nil
+}
+
+func (p *HllServiceExpireAtArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceExpireAtArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Exp = &ExpireAtCmd{}
+	if err := p.Exp.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Exp), err)
+	}
+	return nil
+}
+
+func (p *HllServiceExpireAtArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ExpireAt_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceExpireAtArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "exp", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:exp: ", p), err)
+	}
+	if err := p.Exp.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Exp), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:exp: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceExpireAtArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceExpireAtArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceExpireAtResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceExpireAtResult() *HllServiceExpireAtResult {
+	return &HllServiceExpireAtResult{}
+}
+
+var HllServiceExpireAtResult_Success_DEFAULT Status
+
+func (p *HllServiceExpireAtResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceExpireAtResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceExpireAtResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceExpireAtResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceExpireAtResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceExpireAtResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ExpireAt_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceExpireAtResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceExpireAtResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceExpireAtResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+type HllServiceGetTTLArgs struct {
+	Key string `thrift:"Key,1" db:"Key" json:"Key"`
+}
+
+func NewHllServiceGetTTLArgs() *HllServiceGetTTLArgs {
+	return &HllServiceGetTTLArgs{}
+}
+
+func (p *HllServiceGetTTLArgs) GetKey() string {
+	return p.Key
+}
+func (p *HllServiceGetTTLArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetTTLArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *HllServiceGetTTLArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetTTL_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetTTLArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceGetTTLArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetTTLArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceGetTTLResult struct {
+	Success *TTLResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceGetTTLResult() *HllServiceGetTTLResult {
+	return &HllServiceGetTTLResult{}
+}
+
+var HllServiceGetTTLResult_Success_DEFAULT *TTLResponse
+
+func (p *HllServiceGetTTLResult) GetSuccess() *TTLResponse {
+	if !p.IsSetSuccess() {
+		return HllServiceGetTTLResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *HllServiceGetTTLResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceGetTTLResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetTTLResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &TTLResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetTTLResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetTTL_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetTTLResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceGetTTLResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetTTLResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+type HllServicePersistArgs struct {
+	Key string `thrift:"Key,1" db:"Key" json:"Key"`
+}
+
+func NewHllServicePersistArgs() *HllServicePersistArgs {
+	return &HllServicePersistArgs{}
+}
+
+func (p *HllServicePersistArgs) GetKey() string {
+	return p.Key
+}
+func (p *HllServicePersistArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServicePersistArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *HllServicePersistArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Persist_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServicePersistArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServicePersistArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServicePersistArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServicePersistResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServicePersistResult() *HllServicePersistResult {
+	return &HllServicePersistResult{}
+}
+
+var HllServicePersistResult_Success_DEFAULT Status
+
+func (p *HllServicePersistResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServicePersistResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServicePersistResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServicePersistResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServicePersistResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServicePersistResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Persist_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServicePersistResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServicePersistResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServicePersistResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+type HllServiceDelLogArgs struct {
+	Key string `thrift:"key,1" db:"key" json:"key"`
+}
+
+func NewHllServiceDelLogArgs() *HllServiceDelLogArgs {
+	return &HllServiceDelLogArgs{}
+}
+
+func (p *HllServiceDelLogArgs) GetKey() string {
+	return p.Key
+}
+func (p *HllServiceDelLogArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceDelLogArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *HllServiceDelLogArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "DelLog_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceDelLogArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:key: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceDelLogArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceDelLogArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceDelLogResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceDelLogResult() *HllServiceDelLogResult {
+	return &HllServiceDelLogResult{}
+}
+
+var HllServiceDelLogResult_Success_DEFAULT Status
+
+func (p *HllServiceDelLogResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceDelLogResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceDelLogResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceDelLogResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceDelLogResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceDelLogResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "DelLog_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceDelLogResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceDelLogResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceDelLogResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Key
+//   - Estimator
+type HllServiceGetCardinalityArgs struct {
+	Key       string `thrift:"Key,1" db:"Key" json:"Key"`
+	Estimator string `thrift:"Estimator,2" db:"Estimator" json:"Estimator"`
+}
+
+func NewHllServiceGetCardinalityArgs() *HllServiceGetCardinalityArgs {
+	return &HllServiceGetCardinalityArgs{}
+}
+
+func (p *HllServiceGetCardinalityArgs) GetKey() string {
+	return p.Key
+}
+
+func (p *HllServiceGetCardinalityArgs) GetEstimator() string {
+	return p.Estimator
+}
+func (p *HllServiceGetCardinalityArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityArgs) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Estimator = v
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetCardinality_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceGetCardinalityArgs) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Estimator", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Estimator: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Estimator)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Estimator (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Estimator: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceGetCardinalityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetCardinalityArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceGetCardinalityResult struct {
+	Success *CardinalityResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceGetCardinalityResult() *HllServiceGetCardinalityResult {
+	return &HllServiceGetCardinalityResult{}
+}
+
+var HllServiceGetCardinalityResult_Success_DEFAULT *CardinalityResponse
+
+func (p *HllServiceGetCardinalityResult) GetSuccess() *CardinalityResponse {
+	if !p.IsSetSuccess() {
+		return HllServiceGetCardinalityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *HllServiceGetCardinalityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceGetCardinalityResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &CardinalityResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetCardinality_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetCardinalityResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceGetCardinalityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetCardinalityResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Mrg
+type HllServiceMergeArgs struct {
+	Mrg *MergeLogCmd `thrift:"mrg,1" db:"mrg" json:"mrg"`
+}
+
+func NewHllServiceMergeArgs() *HllServiceMergeArgs {
+	return &HllServiceMergeArgs{}
+}
+
+var HllServiceMergeArgs_Mrg_DEFAULT *MergeLogCmd
+
+func (p *HllServiceMergeArgs) GetMrg() *MergeLogCmd {
+	if !p.IsSetMrg() {
+		return HllServiceMergeArgs_Mrg_DEFAULT
+	}
+	return p.Mrg
+}
+func (p *HllServiceMergeArgs) IsSetMrg() bool {
+	return p.Mrg != nil
+}
+
+func (p *HllServiceMergeArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceMergeArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Mrg = &MergeLogCmd{}
+	if err := p.Mrg.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Mrg), err)
+	}
+	return nil
+}
+
+func (p *HllServiceMergeArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Merge_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceMergeArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "mrg", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:mrg: ", p), err)
+	}
+	if err := p.Mrg.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Mrg), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:mrg: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceMergeArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceMergeArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceMergeResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceMergeResult() *HllServiceMergeResult {
+	return &HllServiceMergeResult{}
+}
+
+var HllServiceMergeResult_Success_DEFAULT Status
+
+func (p *HllServiceMergeResult) GetSuccess() Status {
+	if !p.IsSetSuccess() {
+		return HllServiceMergeResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *HllServiceMergeResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceMergeResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceMergeResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
+	}
+	return nil
+}
+
+func (p *HllServiceMergeResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Merge_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceMergeResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceMergeResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceMergeResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Keys
+//   - Estimator
+type HllServiceGetUnionCardinalityArgs struct {
+	Keys      []string `thrift:"Keys,1" db:"Keys" json:"Keys"`
+	Estimator string   `thrift:"Estimator,2" db:"Estimator" json:"Estimator"`
+}
+
+func NewHllServiceGetUnionCardinalityArgs() *HllServiceGetUnionCardinalityArgs {
+	return &HllServiceGetUnionCardinalityArgs{}
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) GetKeys() []string {
+	return p.Keys
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) GetEstimator() string {
+	return p.Estimator
+}
+func (p *HllServiceGetUnionCardinalityArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.LIST {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin(ctx)
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Keys = tSlice
+	for i := 0; i < size; i++ {
+		var _elem78 string
+		if v, err := iprot.ReadString(ctx); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem78 = v
+		}
+		p.Keys = append(p.Keys, _elem78)
+	}
+	if err := iprot.ReadListEnd(ctx); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Estimator = v
+	}
+	return nil
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetUnionCardinality_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Keys", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Keys: ", p), err)
+	}
+	if err := oprot.WriteListBegin(ctx, thrift.STRING, len(p.Keys)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Keys {
+		if err := oprot.WriteString(ctx, string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(ctx); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Keys: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Estimator", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Estimator: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Estimator)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Estimator (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Estimator: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceGetUnionCardinalityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetUnionCardinalityArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceGetUnionCardinalityResult struct {
+	Success *CardinalityResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceGetUnionCardinalityResult() *HllServiceGetUnionCardinalityResult {
+	return &HllServiceGetUnionCardinalityResult{}
+}
+
+var HllServiceGetUnionCardinalityResult_Success_DEFAULT *CardinalityResponse
+
+func (p *HllServiceGetUnionCardinalityResult) GetSuccess() *CardinalityResponse {
+	if !p.IsSetSuccess() {
+		return HllServiceGetUnionCardinalityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *HllServiceGetUnionCardinalityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceGetUnionCardinalityResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetUnionCardinalityResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &CardinalityResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetUnionCardinalityResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetUnionCardinality_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
 
-	tickerCancel := func() {}
-	// Start a goroutine to do server side connectivity check.
-	if thrift.ServerConnectivityCheckInterval > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithCancel(ctx)
-		defer cancel()
-		var tickerCtx context.Context
-		tickerCtx, tickerCancel = context.WithCancel(context.Background())
-		defer tickerCancel()
-		go func(ctx context.Context, cancel context.CancelFunc) {
-			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if !iprot.Transport().IsOpen() {
-						cancel()
-						return
-					}
+func (p *HllServiceGetUnionCardinalityResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HllServiceGetUnionCardinalityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetUnionCardinalityResult(%+v)", *p)
+}
+
+// Attributes:
+//   - Key1
+//   - Key2
+type HllServiceGetIntersectionCardinalityArgs struct {
+	Key1 string `thrift:"Key1,1" db:"Key1" json:"Key1"`
+	Key2 string `thrift:"Key2,2" db:"Key2" json:"Key2"`
+}
+
+func NewHllServiceGetIntersectionCardinalityArgs() *HllServiceGetIntersectionCardinalityArgs {
+	return &HllServiceGetIntersectionCardinalityArgs{}
+}
+
+func (p *HllServiceGetIntersectionCardinalityArgs) GetKey1() string {
+	return p.Key1
+}
+
+func (p *HllServiceGetIntersectionCardinalityArgs) GetKey2() string {
+	return p.Key2
+}
+func (p *HllServiceGetIntersectionCardinalityArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
 				}
 			}
-		}(tickerCtx, cancel)
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	return nil
+}
 
-	result := HllServiceUpdateMResult{}
-	var retval Status
-	if retval, err2 = p.handler.UpdateM(ctx, args.Mupd); err2 != nil {
-		tickerCancel()
-		if err2 == thrift.ErrAbandonRequest {
-			return false, thrift.WrapTException(err2)
-		}
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateM: "+err2.Error())
-		oprot.WriteMessageBegin(ctx, "UpdateM", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return true, thrift.WrapTException(err2)
+func (p *HllServiceGetIntersectionCardinalityArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		result.Success = &retval
+		p.Key1 = v
 	}
-	tickerCancel()
-	if err2 = oprot.WriteMessageBegin(ctx, "UpdateM", thrift.REPLY, seqId); err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *HllServiceGetIntersectionCardinalityArgs) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Key2 = v
 	}
-	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *HllServiceGetIntersectionCardinalityArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetIntersectionCardinality_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
 	}
-	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err != nil {
-		return
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	return true, err
+	return nil
 }
 
-type hllServiceProcessorUpdateExpiry struct {
-	handler HllService
+func (p *HllServiceGetIntersectionCardinalityArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key1", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key1: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key1)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key1 (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key1: ", p), err)
+	}
+	return err
 }
 
-func (p *hllServiceProcessorUpdateExpiry) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := HllServiceUpdateExpiryArgs{}
-	var err2 error
-	if err2 = args.Read(ctx, iprot); err2 != nil {
-		iprot.ReadMessageEnd(ctx)
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
-		oprot.WriteMessageBegin(ctx, "UpdateExpiry", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return false, thrift.WrapTException(err2)
+func (p *HllServiceGetIntersectionCardinalityArgs) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key2", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key2: ", p), err)
 	}
-	iprot.ReadMessageEnd(ctx)
+	if err := oprot.WriteString(ctx, string(p.Key2)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key2 (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key2: ", p), err)
+	}
+	return err
+}
 
-	tickerCancel := func() {}
-	// Start a goroutine to do server side connectivity check.
-	if thrift.ServerConnectivityCheckInterval > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithCancel(ctx)
-		defer cancel()
-		var tickerCtx context.Context
-		tickerCtx, tickerCancel = context.WithCancel(context.Background())
-		defer tickerCancel()
-		go func(ctx context.Context, cancel context.CancelFunc) {
-			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if !iprot.Transport().IsOpen() {
-						cancel()
-						return
-					}
+func (p *HllServiceGetIntersectionCardinalityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetIntersectionCardinalityArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceGetIntersectionCardinalityResult struct {
+	Success *SetCardinalityResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceGetIntersectionCardinalityResult() *HllServiceGetIntersectionCardinalityResult {
+	return &HllServiceGetIntersectionCardinalityResult{}
+}
+
+var HllServiceGetIntersectionCardinalityResult_Success_DEFAULT *SetCardinalityResponse
+
+func (p *HllServiceGetIntersectionCardinalityResult) GetSuccess() *SetCardinalityResponse {
+	if !p.IsSetSuccess() {
+		return HllServiceGetIntersectionCardinalityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *HllServiceGetIntersectionCardinalityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceGetIntersectionCardinalityResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
 				}
 			}
-		}(tickerCtx, cancel)
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetIntersectionCardinalityResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &SetCardinalityResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *HllServiceGetIntersectionCardinalityResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetIntersectionCardinality_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-
-	result := HllServiceUpdateExpiryResult{}
-	var retval Status
-	if retval, err2 = p.handler.UpdateExpiry(ctx, args.Exp); err2 != nil {
-		tickerCancel()
-		if err2 == thrift.ErrAbandonRequest {
-			return false, thrift.WrapTException(err2)
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
 		}
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateExpiry: "+err2.Error())
-		oprot.WriteMessageBegin(ctx, "UpdateExpiry", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return true, thrift.WrapTException(err2)
-	} else {
-		result.Success = &retval
-	}
-	tickerCancel()
-	if err2 = oprot.WriteMessageBegin(ctx, "UpdateExpiry", thrift.REPLY, seqId); err2 != nil {
-		err = thrift.WrapTException(err2)
 	}
-	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *HllServiceGetIntersectionCardinalityResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *HllServiceGetIntersectionCardinalityResult) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return true, err
+	return fmt.Sprintf("HllServiceGetIntersectionCardinalityResult(%+v)", *p)
 }
 
-type hllServiceProcessorDelLog struct {
-	handler HllService
+// Attributes:
+//   - Key1
+//   - Key2
+type HllServiceGetDifferenceCardinalityArgs struct {
+	Key1 string `thrift:"Key1,1" db:"Key1" json:"Key1"`
+	Key2 string `thrift:"Key2,2" db:"Key2" json:"Key2"`
 }
 
-func (p *hllServiceProcessorDelLog) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := HllServiceDelLogArgs{}
-	var err2 error
-	if err2 = args.Read(ctx, iprot); err2 != nil {
-		iprot.ReadMessageEnd(ctx)
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
-		oprot.WriteMessageBegin(ctx, "DelLog", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return false, thrift.WrapTException(err2)
+func NewHllServiceGetDifferenceCardinalityArgs() *HllServiceGetDifferenceCardinalityArgs {
+	return &HllServiceGetDifferenceCardinalityArgs{}
+}
+
+func (p *HllServiceGetDifferenceCardinalityArgs) GetKey1() string {
+	return p.Key1
+}
+
+func (p *HllServiceGetDifferenceCardinalityArgs) GetKey2() string {
+	return p.Key2
+}
+func (p *HllServiceGetDifferenceCardinalityArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	iprot.ReadMessageEnd(ctx)
 
-	tickerCancel := func() {}
-	// Start a goroutine to do server side connectivity check.
-	if thrift.ServerConnectivityCheckInterval > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithCancel(ctx)
-		defer cancel()
-		var tickerCtx context.Context
-		tickerCtx, tickerCancel = context.WithCancel(context.Background())
-		defer tickerCancel()
-		go func(ctx context.Context, cancel context.CancelFunc) {
-			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if !iprot.Transport().IsOpen() {
-						cancel()
-						return
-					}
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField1(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
 				}
 			}
-		}(tickerCtx, cancel)
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
 	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
 
-	result := HllServiceDelLogResult{}
-	var retval Status
-	if retval, err2 = p.handler.DelLog(ctx, args.Key); err2 != nil {
-		tickerCancel()
-		if err2 == thrift.ErrAbandonRequest {
-			return false, thrift.WrapTException(err2)
-		}
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DelLog: "+err2.Error())
-		oprot.WriteMessageBegin(ctx, "DelLog", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return true, thrift.WrapTException(err2)
+func (p *HllServiceGetDifferenceCardinalityArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		result.Success = &retval
+		p.Key1 = v
 	}
-	tickerCancel()
-	if err2 = oprot.WriteMessageBegin(ctx, "DelLog", thrift.REPLY, seqId); err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *HllServiceGetDifferenceCardinalityArgs) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Key2 = v
 	}
-	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *HllServiceGetDifferenceCardinalityArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetDifferenceCardinality_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if p != nil {
+		if err := p.writeField1(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
 	}
-	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err != nil {
-		return
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	return true, err
+	return nil
 }
 
-type hllServiceProcessorGetCardinality struct {
-	handler HllService
+func (p *HllServiceGetDifferenceCardinalityArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key1", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key1: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Key1)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key1 (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key1: ", p), err)
+	}
+	return err
 }
 
-func (p *hllServiceProcessorGetCardinality) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := HllServiceGetCardinalityArgs{}
-	var err2 error
-	if err2 = args.Read(ctx, iprot); err2 != nil {
-		iprot.ReadMessageEnd(ctx)
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err2.Error())
-		oprot.WriteMessageBegin(ctx, "GetCardinality", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return false, thrift.WrapTException(err2)
+func (p *HllServiceGetDifferenceCardinalityArgs) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key2", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key2: ", p), err)
 	}
-	iprot.ReadMessageEnd(ctx)
+	if err := oprot.WriteString(ctx, string(p.Key2)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key2 (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key2: ", p), err)
+	}
+	return err
+}
 
-	tickerCancel := func() {}
-	// Start a goroutine to do server side connectivity check.
-	if thrift.ServerConnectivityCheckInterval > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithCancel(ctx)
-		defer cancel()
-		var tickerCtx context.Context
-		tickerCtx, tickerCancel = context.WithCancel(context.Background())
-		defer tickerCancel()
-		go func(ctx context.Context, cancel context.CancelFunc) {
-			ticker := time.NewTicker(thrift.ServerConnectivityCheckInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if !iprot.Transport().IsOpen() {
-						cancel()
-						return
-					}
+func (p *HllServiceGetDifferenceCardinalityArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetDifferenceCardinalityArgs(%+v)", *p)
+}
+
+// Attributes:
+//   - Success
+type HllServiceGetDifferenceCardinalityResult struct {
+	Success *SetCardinalityResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewHllServiceGetDifferenceCardinalityResult() *HllServiceGetDifferenceCardinalityResult {
+	return &HllServiceGetDifferenceCardinalityResult{}
+}
+
+var HllServiceGetDifferenceCardinalityResult_Success_DEFAULT *SetCardinalityResponse
+
+func (p *HllServiceGetDifferenceCardinalityResult) GetSuccess() *SetCardinalityResponse {
+	if !p.IsSetSuccess() {
+		return HllServiceGetDifferenceCardinalityResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *HllServiceGetDifferenceCardinalityResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *HllServiceGetDifferenceCardinalityResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin(ctx)
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err := p.ReadField0(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
 				}
 			}
-		}(tickerCtx, cancel)
+		default:
+			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(ctx); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	return nil
+}
 
-	result := HllServiceGetCardinalityResult{}
-	var retval *CardinalityResponse
-	if retval, err2 = p.handler.GetCardinality(ctx, args.Key); err2 != nil {
-		tickerCancel()
-		if err2 == thrift.ErrAbandonRequest {
-			return false, thrift.WrapTException(err2)
-		}
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetCardinality: "+err2.Error())
-		oprot.WriteMessageBegin(ctx, "GetCardinality", thrift.EXCEPTION, seqId)
-		x.Write(ctx, oprot)
-		oprot.WriteMessageEnd(ctx)
-		oprot.Flush(ctx)
-		return true, thrift.WrapTException(err2)
-	} else {
-		result.Success = retval
+func (p *HllServiceGetDifferenceCardinalityResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &SetCardinalityResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
-	tickerCancel()
-	if err2 = oprot.WriteMessageBegin(ctx, "GetCardinality", thrift.REPLY, seqId); err2 != nil {
-		err = thrift.WrapTException(err2)
+	return nil
+}
+
+func (p *HllServiceGetDifferenceCardinalityResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetDifferenceCardinality_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = result.Write(ctx, oprot); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if p != nil {
+		if err := p.writeField0(ctx, oprot); err != nil {
+			return err
+		}
 	}
-	if err2 = oprot.WriteMessageEnd(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteFieldStop(ctx); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
-		err = thrift.WrapTException(err2)
+	if err := oprot.WriteStructEnd(ctx); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err != nil {
-		return
+	return nil
+}
+
+func (p *HllServiceGetDifferenceCardinalityResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(ctx); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	return true, err
+	return err
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *HllServiceGetDifferenceCardinalityResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HllServiceGetDifferenceCardinalityResult(%+v)", *p)
+}
 
 // Attributes:
-//   - AddLog
-type HllServiceAddLogArgs struct {
-	AddLog *AddLogCmd `thrift:"addLog,1" db:"addLog" json:"addLog"`
+//   - Key
+type HllServiceExportLogArgs struct {
+	Key string `thrift:"Key,1" db:"Key" json:"Key"`
 }
 
-func NewHllServiceAddLogArgs() *HllServiceAddLogArgs {
-	return &HllServiceAddLogArgs{}
+func NewHllServiceExportLogArgs() *HllServiceExportLogArgs {
+	return &HllServiceExportLogArgs{}
 }
 
-var HllServiceAddLogArgs_AddLog_DEFAULT *AddLogCmd
-
-func (p *HllServiceAddLogArgs) GetAddLog() *AddLogCmd {
-	if !p.IsSetAddLog() {
-		return HllServiceAddLogArgs_AddLog_DEFAULT
-	}
-	return p.AddLog
-}
-func (p *HllServiceAddLogArgs) IsSetAddLog() bool {
-	return p.AddLog != nil
+func (p *HllServiceExportLogArgs) GetKey() string {
+	return p.Key
 }
-
-func (p *HllServiceAddLogArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceExportLogArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1733,7 +9503,7 @@ func (p *HllServiceAddLogArgs) Read(ctx context.Context, iprot thrift.TProtocol)
 		}
 		switch fieldId {
 		case 1:
-			if fieldTypeId == thrift.STRUCT {
+			if fieldTypeId == thrift.STRING {
 				if err := p.ReadField1(ctx, iprot); err != nil {
 					return err
 				}
@@ -1757,16 +9527,17 @@ func (p *HllServiceAddLogArgs) Read(ctx context.Context, iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *HllServiceAddLogArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
-	p.AddLog = &AddLogCmd{}
-	if err := p.AddLog.Read(ctx, iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.AddLog), err)
+func (p *HllServiceExportLogArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
 	}
 	return nil
 }
 
-func (p *HllServiceAddLogArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "AddLog_args"); err != nil {
+func (p *HllServiceExportLogArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ExportLog_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -1783,49 +9554,49 @@ func (p *HllServiceAddLogArgs) Write(ctx context.Context, oprot thrift.TProtocol
 	return nil
 }
 
-func (p *HllServiceAddLogArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "addLog", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:addLog: ", p), err)
+func (p *HllServiceExportLogArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
-	if err := p.AddLog.Write(ctx, oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.AddLog), err)
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:addLog: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
 	}
 	return err
 }
 
-func (p *HllServiceAddLogArgs) String() string {
+func (p *HllServiceExportLogArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceAddLogArgs(%+v)", *p)
+	return fmt.Sprintf("HllServiceExportLogArgs(%+v)", *p)
 }
 
 // Attributes:
 //   - Success
-type HllServiceAddLogResult struct {
-	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HllServiceExportLogResult struct {
+	Success *SketchResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
 }
 
-func NewHllServiceAddLogResult() *HllServiceAddLogResult {
-	return &HllServiceAddLogResult{}
+func NewHllServiceExportLogResult() *HllServiceExportLogResult {
+	return &HllServiceExportLogResult{}
 }
 
-var HllServiceAddLogResult_Success_DEFAULT Status
+var HllServiceExportLogResult_Success_DEFAULT *SketchResponse
 
-func (p *HllServiceAddLogResult) GetSuccess() Status {
+func (p *HllServiceExportLogResult) GetSuccess() *SketchResponse {
 	if !p.IsSetSuccess() {
-		return HllServiceAddLogResult_Success_DEFAULT
+		return HllServiceExportLogResult_Success_DEFAULT
 	}
-	return *p.Success
+	return p.Success
 }
-func (p *HllServiceAddLogResult) IsSetSuccess() bool {
+func (p *HllServiceExportLogResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *HllServiceAddLogResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceExportLogResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1840,7 +9611,7 @@ func (p *HllServiceAddLogResult) Read(ctx context.Context, iprot thrift.TProtoco
 		}
 		switch fieldId {
 		case 0:
-			if fieldTypeId == thrift.I32 {
+			if fieldTypeId == thrift.STRUCT {
 				if err := p.ReadField0(ctx, iprot); err != nil {
 					return err
 				}
@@ -1864,18 +9635,16 @@ func (p *HllServiceAddLogResult) Read(ctx context.Context, iprot thrift.TProtoco
 	return nil
 }
 
-func (p *HllServiceAddLogResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(ctx); err != nil {
-		return thrift.PrependError("error reading field 0: ", err)
-	} else {
-		temp := Status(v)
-		p.Success = &temp
+func (p *HllServiceExportLogResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &SketchResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *HllServiceAddLogResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "AddLog_result"); err != nil {
+func (p *HllServiceExportLogResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ExportLog_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -1892,13 +9661,13 @@ func (p *HllServiceAddLogResult) Write(ctx context.Context, oprot thrift.TProtoc
 	return nil
 }
 
-func (p *HllServiceAddLogResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *HllServiceExportLogResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
 		}
-		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
 		}
 		if err := oprot.WriteFieldEnd(ctx); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
@@ -1907,36 +9676,36 @@ func (p *HllServiceAddLogResult) writeField0(ctx context.Context, oprot thrift.T
 	return err
 }
 
-func (p *HllServiceAddLogResult) String() string {
+func (p *HllServiceExportLogResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceAddLogResult(%+v)", *p)
+	return fmt.Sprintf("HllServiceExportLogResult(%+v)", *p)
 }
 
 // Attributes:
-//   - Upd
-type HllServiceUpdateArgs struct {
-	Upd *UpdateLogCmd `thrift:"upd,1" db:"upd" json:"upd"`
+//   - Imp
+type HllServiceImportLogArgs struct {
+	Imp *ImportLogCmd `thrift:"imp,1" db:"imp" json:"imp"`
 }
 
-func NewHllServiceUpdateArgs() *HllServiceUpdateArgs {
-	return &HllServiceUpdateArgs{}
+func NewHllServiceImportLogArgs() *HllServiceImportLogArgs {
+	return &HllServiceImportLogArgs{}
 }
 
-var HllServiceUpdateArgs_Upd_DEFAULT *UpdateLogCmd
+var HllServiceImportLogArgs_Imp_DEFAULT *ImportLogCmd
 
-func (p *HllServiceUpdateArgs) GetUpd() *UpdateLogCmd {
-	if !p.IsSetUpd() {
-		return HllServiceUpdateArgs_Upd_DEFAULT
+func (p *HllServiceImportLogArgs) GetImp() *ImportLogCmd {
+	if !p.IsSetImp() {
+		return HllServiceImportLogArgs_Imp_DEFAULT
 	}
-	return p.Upd
+	return p.Imp
 }
-func (p *HllServiceUpdateArgs) IsSetUpd() bool {
-	return p.Upd != nil
+func (p *HllServiceImportLogArgs) IsSetImp() bool {
+	return p.Imp != nil
 }
 
-func (p *HllServiceUpdateArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceImportLogArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1975,16 +9744,16 @@ func (p *HllServiceUpdateArgs) Read(ctx context.Context, iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *HllServiceUpdateArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
-	p.Upd = &UpdateLogCmd{}
-	if err := p.Upd.Read(ctx, iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Upd), err)
+func (p *HllServiceImportLogArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Imp = &ImportLogCmd{}
+	if err := p.Imp.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Imp), err)
 	}
 	return nil
 }
 
-func (p *HllServiceUpdateArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "Update_args"); err != nil {
+func (p *HllServiceImportLogArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ImportLog_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2001,49 +9770,49 @@ func (p *HllServiceUpdateArgs) Write(ctx context.Context, oprot thrift.TProtocol
 	return nil
 }
 
-func (p *HllServiceUpdateArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "upd", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:upd: ", p), err)
+func (p *HllServiceImportLogArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "imp", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:imp: ", p), err)
 	}
-	if err := p.Upd.Write(ctx, oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Upd), err)
+	if err := p.Imp.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Imp), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:upd: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:imp: ", p), err)
 	}
 	return err
 }
 
-func (p *HllServiceUpdateArgs) String() string {
+func (p *HllServiceImportLogArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceUpdateArgs(%+v)", *p)
+	return fmt.Sprintf("HllServiceImportLogArgs(%+v)", *p)
 }
 
 // Attributes:
 //   - Success
-type HllServiceUpdateResult struct {
+type HllServiceImportLogResult struct {
 	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
 }
 
-func NewHllServiceUpdateResult() *HllServiceUpdateResult {
-	return &HllServiceUpdateResult{}
+func NewHllServiceImportLogResult() *HllServiceImportLogResult {
+	return &HllServiceImportLogResult{}
 }
 
-var HllServiceUpdateResult_Success_DEFAULT Status
+var HllServiceImportLogResult_Success_DEFAULT Status
 
-func (p *HllServiceUpdateResult) GetSuccess() Status {
+func (p *HllServiceImportLogResult) GetSuccess() Status {
 	if !p.IsSetSuccess() {
-		return HllServiceUpdateResult_Success_DEFAULT
+		return HllServiceImportLogResult_Success_DEFAULT
 	}
 	return *p.Success
 }
-func (p *HllServiceUpdateResult) IsSetSuccess() bool {
+func (p *HllServiceImportLogResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *HllServiceUpdateResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceImportLogResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2082,7 +9851,7 @@ func (p *HllServiceUpdateResult) Read(ctx context.Context, iprot thrift.TProtoco
 	return nil
 }
 
-func (p *HllServiceUpdateResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceImportLogResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(ctx); err != nil {
 		return thrift.PrependError("error reading field 0: ", err)
 	} else {
@@ -2092,8 +9861,8 @@ func (p *HllServiceUpdateResult) ReadField0(ctx context.Context, iprot thrift.TP
 	return nil
 }
 
-func (p *HllServiceUpdateResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "Update_result"); err != nil {
+func (p *HllServiceImportLogResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "ImportLog_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2110,7 +9879,7 @@ func (p *HllServiceUpdateResult) Write(ctx context.Context, oprot thrift.TProtoc
 	return nil
 }
 
-func (p *HllServiceUpdateResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *HllServiceImportLogResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -2125,36 +9894,39 @@ func (p *HllServiceUpdateResult) writeField0(ctx context.Context, oprot thrift.T
 	return err
 }
 
-func (p *HllServiceUpdateResult) String() string {
+func (p *HllServiceImportLogResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceUpdateResult(%+v)", *p)
+	return fmt.Sprintf("HllServiceImportLogResult(%+v)", *p)
 }
 
 // Attributes:
-//   - Mupd
-type HllServiceUpdateMArgs struct {
-	Mupd *UpdateLogMValCmd `thrift:"mupd,1" db:"mupd" json:"mupd"`
+//   - Cursor
+//   - Match
+//   - Count
+type HllServiceScanArgs struct {
+	Cursor string `thrift:"Cursor,1" db:"Cursor" json:"Cursor"`
+	Match  string `thrift:"Match,2" db:"Match" json:"Match"`
+	Count  int32  `thrift:"Count,3" db:"Count" json:"Count"`
 }
 
-func NewHllServiceUpdateMArgs() *HllServiceUpdateMArgs {
-	return &HllServiceUpdateMArgs{}
+func NewHllServiceScanArgs() *HllServiceScanArgs {
+	return &HllServiceScanArgs{}
 }
 
-var HllServiceUpdateMArgs_Mupd_DEFAULT *UpdateLogMValCmd
-
-func (p *HllServiceUpdateMArgs) GetMupd() *UpdateLogMValCmd {
-	if !p.IsSetMupd() {
-		return HllServiceUpdateMArgs_Mupd_DEFAULT
-	}
-	return p.Mupd
+func (p *HllServiceScanArgs) GetCursor() string {
+	return p.Cursor
 }
-func (p *HllServiceUpdateMArgs) IsSetMupd() bool {
-	return p.Mupd != nil
+
+func (p *HllServiceScanArgs) GetMatch() string {
+	return p.Match
 }
 
-func (p *HllServiceUpdateMArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceScanArgs) GetCount() int32 {
+	return p.Count
+}
+func (p *HllServiceScanArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2169,7 +9941,7 @@ func (p *HllServiceUpdateMArgs) Read(ctx context.Context, iprot thrift.TProtocol
 		}
 		switch fieldId {
 		case 1:
-			if fieldTypeId == thrift.STRUCT {
+			if fieldTypeId == thrift.STRING {
 				if err := p.ReadField1(ctx, iprot); err != nil {
 					return err
 				}
@@ -2178,6 +9950,26 @@ func (p *HllServiceUpdateMArgs) Read(ctx context.Context, iprot thrift.TProtocol
 					return err
 				}
 			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err := p.ReadField2(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if fieldTypeId == thrift.I32 {
+				if err := p.ReadField3(ctx, iprot); err != nil {
+					return err
+				}
+			} else {
+				if err := iprot.Skip(ctx, fieldTypeId); err != nil {
+					return err
+				}
+			}
 		default:
 			if err := iprot.Skip(ctx, fieldTypeId); err != nil {
 				return err
@@ -2193,22 +9985,47 @@ func (p *HllServiceUpdateMArgs) Read(ctx context.Context, iprot thrift.TProtocol
 	return nil
 }
 
-func (p *HllServiceUpdateMArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
-	p.Mupd = &UpdateLogMValCmd{}
-	if err := p.Mupd.Read(ctx, iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Mupd), err)
+func (p *HllServiceScanArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Cursor = v
+	}
+	return nil
+}
+
+func (p *HllServiceScanArgs) ReadField2(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Match = v
+	}
+	return nil
+}
+
+func (p *HllServiceScanArgs) ReadField3(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Count = v
 	}
 	return nil
 }
 
-func (p *HllServiceUpdateMArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "UpdateM_args"); err != nil {
+func (p *HllServiceScanArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Scan_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(ctx, oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(ctx, oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(ctx, oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(ctx); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -2219,49 +10036,75 @@ func (p *HllServiceUpdateMArgs) Write(ctx context.Context, oprot thrift.TProtoco
 	return nil
 }
 
-func (p *HllServiceUpdateMArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "mupd", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:mupd: ", p), err)
+func (p *HllServiceScanArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Cursor", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Cursor: ", p), err)
 	}
-	if err := p.Mupd.Write(ctx, oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Mupd), err)
+	if err := oprot.WriteString(ctx, string(p.Cursor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Cursor (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:mupd: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Cursor: ", p), err)
 	}
 	return err
 }
 
-func (p *HllServiceUpdateMArgs) String() string {
+func (p *HllServiceScanArgs) writeField2(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Match", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Match: ", p), err)
+	}
+	if err := oprot.WriteString(ctx, string(p.Match)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Match (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Match: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceScanArgs) writeField3(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Count", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Count: ", p), err)
+	}
+	if err := oprot.WriteI32(ctx, int32(p.Count)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Count (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(ctx); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Count: ", p), err)
+	}
+	return err
+}
+
+func (p *HllServiceScanArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceUpdateMArgs(%+v)", *p)
+	return fmt.Sprintf("HllServiceScanArgs(%+v)", *p)
 }
 
 // Attributes:
 //   - Success
-type HllServiceUpdateMResult struct {
-	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HllServiceScanResult struct {
+	Success *ScanResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
 }
 
-func NewHllServiceUpdateMResult() *HllServiceUpdateMResult {
-	return &HllServiceUpdateMResult{}
+func NewHllServiceScanResult() *HllServiceScanResult {
+	return &HllServiceScanResult{}
 }
 
-var HllServiceUpdateMResult_Success_DEFAULT Status
+var HllServiceScanResult_Success_DEFAULT *ScanResponse
 
-func (p *HllServiceUpdateMResult) GetSuccess() Status {
+func (p *HllServiceScanResult) GetSuccess() *ScanResponse {
 	if !p.IsSetSuccess() {
-		return HllServiceUpdateMResult_Success_DEFAULT
+		return HllServiceScanResult_Success_DEFAULT
 	}
-	return *p.Success
+	return p.Success
 }
-func (p *HllServiceUpdateMResult) IsSetSuccess() bool {
+func (p *HllServiceScanResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *HllServiceUpdateMResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceScanResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2276,7 +10119,7 @@ func (p *HllServiceUpdateMResult) Read(ctx context.Context, iprot thrift.TProtoc
 		}
 		switch fieldId {
 		case 0:
-			if fieldTypeId == thrift.I32 {
+			if fieldTypeId == thrift.STRUCT {
 				if err := p.ReadField0(ctx, iprot); err != nil {
 					return err
 				}
@@ -2300,18 +10143,16 @@ func (p *HllServiceUpdateMResult) Read(ctx context.Context, iprot thrift.TProtoc
 	return nil
 }
 
-func (p *HllServiceUpdateMResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(ctx); err != nil {
-		return thrift.PrependError("error reading field 0: ", err)
-	} else {
-		temp := Status(v)
-		p.Success = &temp
+func (p *HllServiceScanResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &ScanResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *HllServiceUpdateMResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "UpdateM_result"); err != nil {
+func (p *HllServiceScanResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Scan_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2328,13 +10169,13 @@ func (p *HllServiceUpdateMResult) Write(ctx context.Context, oprot thrift.TProto
 	return nil
 }
 
-func (p *HllServiceUpdateMResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *HllServiceScanResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
 		}
-		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
 		}
 		if err := oprot.WriteFieldEnd(ctx); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
@@ -2343,36 +10184,27 @@ func (p *HllServiceUpdateMResult) writeField0(ctx context.Context, oprot thrift.
 	return err
 }
 
-func (p *HllServiceUpdateMResult) String() string {
+func (p *HllServiceScanResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceUpdateMResult(%+v)", *p)
+	return fmt.Sprintf("HllServiceScanResult(%+v)", *p)
 }
 
 // Attributes:
-//   - Exp
-type HllServiceUpdateExpiryArgs struct {
-	Exp *UpdateExpiryCmd `thrift:"exp,1" db:"exp" json:"exp"`
+//   - Key
+type HllServiceGetLogInfoArgs struct {
+	Key string `thrift:"Key,1" db:"Key" json:"Key"`
 }
 
-func NewHllServiceUpdateExpiryArgs() *HllServiceUpdateExpiryArgs {
-	return &HllServiceUpdateExpiryArgs{}
+func NewHllServiceGetLogInfoArgs() *HllServiceGetLogInfoArgs {
+	return &HllServiceGetLogInfoArgs{}
 }
 
-var HllServiceUpdateExpiryArgs_Exp_DEFAULT *UpdateExpiryCmd
-
-func (p *HllServiceUpdateExpiryArgs) GetExp() *UpdateExpiryCmd {
-	if !p.IsSetExp() {
-		return HllServiceUpdateExpiryArgs_Exp_DEFAULT
-	}
-	return p.Exp
-}
-func (p *HllServiceUpdateExpiryArgs) IsSetExp() bool {
-	return p.Exp != nil
+func (p *HllServiceGetLogInfoArgs) GetKey() string {
+	return p.Key
 }
-
-func (p *HllServiceUpdateExpiryArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceGetLogInfoArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2387,7 +10219,7 @@ func (p *HllServiceUpdateExpiryArgs) Read(ctx context.Context, iprot thrift.TPro
 		}
 		switch fieldId {
 		case 1:
-			if fieldTypeId == thrift.STRUCT {
+			if fieldTypeId == thrift.STRING {
 				if err := p.ReadField1(ctx, iprot); err != nil {
 					return err
 				}
@@ -2411,16 +10243,17 @@ func (p *HllServiceUpdateExpiryArgs) Read(ctx context.Context, iprot thrift.TPro
 	return nil
 }
 
-func (p *HllServiceUpdateExpiryArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
-	p.Exp = &UpdateExpiryCmd{}
-	if err := p.Exp.Read(ctx, iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Exp), err)
+func (p *HllServiceGetLogInfoArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(ctx); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
 	}
 	return nil
 }
 
-func (p *HllServiceUpdateExpiryArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "UpdateExpiry_args"); err != nil {
+func (p *HllServiceGetLogInfoArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetLogInfo_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2437,49 +10270,49 @@ func (p *HllServiceUpdateExpiryArgs) Write(ctx context.Context, oprot thrift.TPr
 	return nil
 }
 
-func (p *HllServiceUpdateExpiryArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "exp", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:exp: ", p), err)
+func (p *HllServiceGetLogInfoArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
-	if err := p.Exp.Write(ctx, oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Exp), err)
+	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:exp: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
 	}
 	return err
 }
 
-func (p *HllServiceUpdateExpiryArgs) String() string {
+func (p *HllServiceGetLogInfoArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceUpdateExpiryArgs(%+v)", *p)
+	return fmt.Sprintf("HllServiceGetLogInfoArgs(%+v)", *p)
 }
 
 // Attributes:
 //   - Success
-type HllServiceUpdateExpiryResult struct {
-	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HllServiceGetLogInfoResult struct {
+	Success *LogInfoResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
 }
 
-func NewHllServiceUpdateExpiryResult() *HllServiceUpdateExpiryResult {
-	return &HllServiceUpdateExpiryResult{}
+func NewHllServiceGetLogInfoResult() *HllServiceGetLogInfoResult {
+	return &HllServiceGetLogInfoResult{}
 }
 
-var HllServiceUpdateExpiryResult_Success_DEFAULT Status
+var HllServiceGetLogInfoResult_Success_DEFAULT *LogInfoResponse
 
-func (p *HllServiceUpdateExpiryResult) GetSuccess() Status {
+func (p *HllServiceGetLogInfoResult) GetSuccess() *LogInfoResponse {
 	if !p.IsSetSuccess() {
-		return HllServiceUpdateExpiryResult_Success_DEFAULT
+		return HllServiceGetLogInfoResult_Success_DEFAULT
 	}
-	return *p.Success
+	return p.Success
 }
-func (p *HllServiceUpdateExpiryResult) IsSetSuccess() bool {
+func (p *HllServiceGetLogInfoResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *HllServiceUpdateExpiryResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceGetLogInfoResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2494,7 +10327,7 @@ func (p *HllServiceUpdateExpiryResult) Read(ctx context.Context, iprot thrift.TP
 		}
 		switch fieldId {
 		case 0:
-			if fieldTypeId == thrift.I32 {
+			if fieldTypeId == thrift.STRUCT {
 				if err := p.ReadField0(ctx, iprot); err != nil {
 					return err
 				}
@@ -2518,18 +10351,16 @@ func (p *HllServiceUpdateExpiryResult) Read(ctx context.Context, iprot thrift.TP
 	return nil
 }
 
-func (p *HllServiceUpdateExpiryResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(ctx); err != nil {
-		return thrift.PrependError("error reading field 0: ", err)
-	} else {
-		temp := Status(v)
-		p.Success = &temp
+func (p *HllServiceGetLogInfoResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Success = &LogInfoResponse{}
+	if err := p.Success.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *HllServiceUpdateExpiryResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "UpdateExpiry_result"); err != nil {
+func (p *HllServiceGetLogInfoResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "GetLogInfo_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2546,13 +10377,13 @@ func (p *HllServiceUpdateExpiryResult) Write(ctx context.Context, oprot thrift.T
 	return nil
 }
 
-func (p *HllServiceUpdateExpiryResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *HllServiceGetLogInfoResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
 		}
-		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		if err := p.Success.Write(ctx, oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
 		}
 		if err := oprot.WriteFieldEnd(ctx); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
@@ -2561,27 +10392,36 @@ func (p *HllServiceUpdateExpiryResult) writeField0(ctx context.Context, oprot th
 	return err
 }
 
-func (p *HllServiceUpdateExpiryResult) String() string {
+func (p *HllServiceGetLogInfoResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceUpdateExpiryResult(%+v)", *p)
+	return fmt.Sprintf("HllServiceGetLogInfoResult(%+v)", *p)
 }
 
 // Attributes:
-//   - Key
-type HllServiceDelLogArgs struct {
-	Key string `thrift:"key,1" db:"key" json:"key"`
+//   - Ren
+type HllServiceRenameArgs struct {
+	Ren *RenameLogCmd `thrift:"ren,1" db:"ren" json:"ren"`
 }
 
-func NewHllServiceDelLogArgs() *HllServiceDelLogArgs {
-	return &HllServiceDelLogArgs{}
+func NewHllServiceRenameArgs() *HllServiceRenameArgs {
+	return &HllServiceRenameArgs{}
 }
 
-func (p *HllServiceDelLogArgs) GetKey() string {
-	return p.Key
+var HllServiceRenameArgs_Ren_DEFAULT *RenameLogCmd
+
+func (p *HllServiceRenameArgs) GetRen() *RenameLogCmd {
+	if !p.IsSetRen() {
+		return HllServiceRenameArgs_Ren_DEFAULT
+	}
+	return p.Ren
 }
-func (p *HllServiceDelLogArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceRenameArgs) IsSetRen() bool {
+	return p.Ren != nil
+}
+
+func (p *HllServiceRenameArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2596,7 +10436,7 @@ func (p *HllServiceDelLogArgs) Read(ctx context.Context, iprot thrift.TProtocol)
 		}
 		switch fieldId {
 		case 1:
-			if fieldTypeId == thrift.STRING {
+			if fieldTypeId == thrift.STRUCT {
 				if err := p.ReadField1(ctx, iprot); err != nil {
 					return err
 				}
@@ -2620,17 +10460,16 @@ func (p *HllServiceDelLogArgs) Read(ctx context.Context, iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *HllServiceDelLogArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(ctx); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.Key = v
+func (p *HllServiceRenameArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Ren = &RenameLogCmd{}
+	if err := p.Ren.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ren), err)
 	}
 	return nil
 }
 
-func (p *HllServiceDelLogArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "DelLog_args"); err != nil {
+func (p *HllServiceRenameArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Rename_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2647,49 +10486,49 @@ func (p *HllServiceDelLogArgs) Write(ctx context.Context, oprot thrift.TProtocol
 	return nil
 }
 
-func (p *HllServiceDelLogArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "key", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:key: ", p), err)
+func (p *HllServiceRenameArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "ren", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ren: ", p), err)
 	}
-	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.key (1) field write error: ", p), err)
+	if err := p.Ren.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ren), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:key: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ren: ", p), err)
 	}
 	return err
 }
 
-func (p *HllServiceDelLogArgs) String() string {
+func (p *HllServiceRenameArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceDelLogArgs(%+v)", *p)
+	return fmt.Sprintf("HllServiceRenameArgs(%+v)", *p)
 }
 
 // Attributes:
 //   - Success
-type HllServiceDelLogResult struct {
+type HllServiceRenameResult struct {
 	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
 }
 
-func NewHllServiceDelLogResult() *HllServiceDelLogResult {
-	return &HllServiceDelLogResult{}
+func NewHllServiceRenameResult() *HllServiceRenameResult {
+	return &HllServiceRenameResult{}
 }
 
-var HllServiceDelLogResult_Success_DEFAULT Status
+var HllServiceRenameResult_Success_DEFAULT Status
 
-func (p *HllServiceDelLogResult) GetSuccess() Status {
+func (p *HllServiceRenameResult) GetSuccess() Status {
 	if !p.IsSetSuccess() {
-		return HllServiceDelLogResult_Success_DEFAULT
+		return HllServiceRenameResult_Success_DEFAULT
 	}
 	return *p.Success
 }
-func (p *HllServiceDelLogResult) IsSetSuccess() bool {
+func (p *HllServiceRenameResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *HllServiceDelLogResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceRenameResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2728,7 +10567,7 @@ func (p *HllServiceDelLogResult) Read(ctx context.Context, iprot thrift.TProtoco
 	return nil
 }
 
-func (p *HllServiceDelLogResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceRenameResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(ctx); err != nil {
 		return thrift.PrependError("error reading field 0: ", err)
 	} else {
@@ -2738,8 +10577,8 @@ func (p *HllServiceDelLogResult) ReadField0(ctx context.Context, iprot thrift.TP
 	return nil
 }
 
-func (p *HllServiceDelLogResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "DelLog_result"); err != nil {
+func (p *HllServiceRenameResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Rename_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2756,7 +10595,7 @@ func (p *HllServiceDelLogResult) Write(ctx context.Context, oprot thrift.TProtoc
 	return nil
 }
 
-func (p *HllServiceDelLogResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *HllServiceRenameResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -2771,27 +10610,36 @@ func (p *HllServiceDelLogResult) writeField0(ctx context.Context, oprot thrift.T
 	return err
 }
 
-func (p *HllServiceDelLogResult) String() string {
+func (p *HllServiceRenameResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceDelLogResult(%+v)", *p)
+	return fmt.Sprintf("HllServiceRenameResult(%+v)", *p)
 }
 
 // Attributes:
-//   - Key
-type HllServiceGetCardinalityArgs struct {
-	Key string `thrift:"Key,1" db:"Key" json:"Key"`
+//   - Cpy
+type HllServiceCopyArgs struct {
+	Cpy *CopyLogCmd `thrift:"cpy,1" db:"cpy" json:"cpy"`
 }
 
-func NewHllServiceGetCardinalityArgs() *HllServiceGetCardinalityArgs {
-	return &HllServiceGetCardinalityArgs{}
+func NewHllServiceCopyArgs() *HllServiceCopyArgs {
+	return &HllServiceCopyArgs{}
 }
 
-func (p *HllServiceGetCardinalityArgs) GetKey() string {
-	return p.Key
+var HllServiceCopyArgs_Cpy_DEFAULT *CopyLogCmd
+
+func (p *HllServiceCopyArgs) GetCpy() *CopyLogCmd {
+	if !p.IsSetCpy() {
+		return HllServiceCopyArgs_Cpy_DEFAULT
+	}
+	return p.Cpy
 }
-func (p *HllServiceGetCardinalityArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceCopyArgs) IsSetCpy() bool {
+	return p.Cpy != nil
+}
+
+func (p *HllServiceCopyArgs) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2806,7 +10654,7 @@ func (p *HllServiceGetCardinalityArgs) Read(ctx context.Context, iprot thrift.TP
 		}
 		switch fieldId {
 		case 1:
-			if fieldTypeId == thrift.STRING {
+			if fieldTypeId == thrift.STRUCT {
 				if err := p.ReadField1(ctx, iprot); err != nil {
 					return err
 				}
@@ -2830,17 +10678,16 @@ func (p *HllServiceGetCardinalityArgs) Read(ctx context.Context, iprot thrift.TP
 	return nil
 }
 
-func (p *HllServiceGetCardinalityArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(ctx); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.Key = v
+func (p *HllServiceCopyArgs) ReadField1(ctx context.Context, iprot thrift.TProtocol) error {
+	p.Cpy = &CopyLogCmd{}
+	if err := p.Cpy.Read(ctx, iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cpy), err)
 	}
 	return nil
 }
 
-func (p *HllServiceGetCardinalityArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "GetCardinality_args"); err != nil {
+func (p *HllServiceCopyArgs) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Copy_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2857,49 +10704,49 @@ func (p *HllServiceGetCardinalityArgs) Write(ctx context.Context, oprot thrift.T
 	return nil
 }
 
-func (p *HllServiceGetCardinalityArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin(ctx, "Key", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+func (p *HllServiceCopyArgs) writeField1(ctx context.Context, oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin(ctx, "cpy", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cpy: ", p), err)
 	}
-	if err := oprot.WriteString(ctx, string(p.Key)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	if err := p.Cpy.Write(ctx, oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cpy), err)
 	}
 	if err := oprot.WriteFieldEnd(ctx); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cpy: ", p), err)
 	}
 	return err
 }
 
-func (p *HllServiceGetCardinalityArgs) String() string {
+func (p *HllServiceCopyArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceGetCardinalityArgs(%+v)", *p)
+	return fmt.Sprintf("HllServiceCopyArgs(%+v)", *p)
 }
 
 // Attributes:
 //   - Success
-type HllServiceGetCardinalityResult struct {
-	Success *CardinalityResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HllServiceCopyResult struct {
+	Success *Status `thrift:"success,0" db:"success" json:"success,omitempty"`
 }
 
-func NewHllServiceGetCardinalityResult() *HllServiceGetCardinalityResult {
-	return &HllServiceGetCardinalityResult{}
+func NewHllServiceCopyResult() *HllServiceCopyResult {
+	return &HllServiceCopyResult{}
 }
 
-var HllServiceGetCardinalityResult_Success_DEFAULT *CardinalityResponse
+var HllServiceCopyResult_Success_DEFAULT Status
 
-func (p *HllServiceGetCardinalityResult) GetSuccess() *CardinalityResponse {
+func (p *HllServiceCopyResult) GetSuccess() Status {
 	if !p.IsSetSuccess() {
-		return HllServiceGetCardinalityResult_Success_DEFAULT
+		return HllServiceCopyResult_Success_DEFAULT
 	}
-	return p.Success
+	return *p.Success
 }
-func (p *HllServiceGetCardinalityResult) IsSetSuccess() bool {
+func (p *HllServiceCopyResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *HllServiceGetCardinalityResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
+func (p *HllServiceCopyResult) Read(ctx context.Context, iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(ctx); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2914,7 +10761,7 @@ func (p *HllServiceGetCardinalityResult) Read(ctx context.Context, iprot thrift.
 		}
 		switch fieldId {
 		case 0:
-			if fieldTypeId == thrift.STRUCT {
+			if fieldTypeId == thrift.I32 {
 				if err := p.ReadField0(ctx, iprot); err != nil {
 					return err
 				}
@@ -2938,16 +10785,18 @@ func (p *HllServiceGetCardinalityResult) Read(ctx context.Context, iprot thrift.
 	return nil
 }
 
-func (p *HllServiceGetCardinalityResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
-	p.Success = &CardinalityResponse{}
-	if err := p.Success.Read(ctx, iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+func (p *HllServiceCopyResult) ReadField0(ctx context.Context, iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(ctx); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		temp := Status(v)
+		p.Success = &temp
 	}
 	return nil
 }
 
-func (p *HllServiceGetCardinalityResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin(ctx, "GetCardinality_result"); err != nil {
+func (p *HllServiceCopyResult) Write(ctx context.Context, oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin(ctx, "Copy_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -2964,13 +10813,13 @@ func (p *HllServiceGetCardinalityResult) Write(ctx context.Context, oprot thrift
 	return nil
 }
 
-func (p *HllServiceGetCardinalityResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
+func (p *HllServiceCopyResult) writeField0(ctx context.Context, oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin(ctx, "success", thrift.STRUCT, 0); err != nil {
+		if err := oprot.WriteFieldBegin(ctx, "success", thrift.I32, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
 		}
-		if err := p.Success.Write(ctx, oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		if err := oprot.WriteI32(ctx, int32(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(ctx); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
@@ -2979,9 +10828,9 @@ func (p *HllServiceGetCardinalityResult) writeField0(ctx context.Context, oprot
 	return err
 }
 
-func (p *HllServiceGetCardinalityResult) String() string {
+func (p *HllServiceCopyResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HllServiceGetCardinalityResult(%+v)", *p)
+	return fmt.Sprintf("HllServiceCopyResult(%+v)", *p)
 }
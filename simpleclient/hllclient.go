@@ -34,7 +34,7 @@ func main() {
 	}
 	ctx := context.Background()
 	client := hllthrift.NewHllServiceClientFactory(trans, protocolFactory)
-	status, err := client.AddLog(ctx, &hllthrift.AddLogCmd{*logkey, 20})
+	status, err := client.AddLog(ctx, &hllthrift.AddLogCmd{Key: *logkey, Expiry: 20})
 	if err != nil {
 		panic(err)
 	}
@@ -77,7 +77,7 @@ func main() {
 		}
 	}
 	fmt.Printf("Sent %d\n", j)
-	cr, err := client.GetCardinality(ctx, *logkey)
+	cr, err := client.GetCardinality(ctx, *logkey, "")
 	if err != nil || cr.Status != hllthrift.Status_SUCCESS {
 		panic("Couldn't get cardinality")
 	}
@@ -66,7 +66,7 @@ func main() {
 		i++
 	}
 
-	status, err := clients[0].AddLog(ctx, &hllthrift.AddLogCmd{*logkey, 0})
+	status, err := clients[0].AddLog(ctx, &hllthrift.AddLogCmd{Key: *logkey})
 	if err != nil || status != hllthrift.Status_SUCCESS {
 		panic("Failed")
 	}
@@ -120,7 +120,7 @@ func main() {
 		time.Sleep(1000 * time.Millisecond)
 		fmt.Printf("Val %d\n", val)
 	}
-	cr, err := clients[0].GetCardinality(ctx, *logkey)
+	cr, err := clients[0].GetCardinality(ctx, *logkey, "")
 	if err != nil || cr.Status != hllthrift.Status_SUCCESS {
 		panic("Couldn't get cardinality")
 	}
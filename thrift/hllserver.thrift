@@ -7,9 +7,18 @@ enum Status {
     KEY_NOT_EXISTS
 }
 
+enum Algorithm {
+    CLASSIC,
+    HLLPP,
+    REDIS
+}
+
 struct AddLogCmd {
     1: string Key,
-    2: i64 Expiry = 0
+    2: i64 Expiry = 0,
+    3: i32 Precision = 0,
+    4: Algorithm Algorithm = Algorithm.CLASSIC,
+    5: binary Salt
 }
 
 struct UpdateLogCmd {
@@ -24,23 +33,121 @@ struct UpdateLogMValCmd {
     3: i64 Expiry = 0
 }
 
+struct UpdateLogHashesCmd {
+    1: string Key,
+    2: list<i64> Hashes,
+    3: i64 Expiry = 0
+}
+
+struct MergeLogCmd {
+    1: string Key,
+    2: list<string> SourceKeys
+}
+
 struct UpdateExpiryCmd {
     1: string Key,
     2: i64 Expiry
 }
 
+struct ExpireAtCmd {
+    1: string Key,
+    2: i64 Timestamp
+}
+
+struct RenameLogCmd {
+    1: string Key,
+    2: string NewKey,
+    3: bool NoOverwrite = false
+}
+
+struct CopyLogCmd {
+    1: string Key,
+    2: string NewKey,
+    3: bool Replace = false
+}
+
 
 struct CardinalityResponse {
     1: string Key
     2: Status Status
     3: i64 Cardinality
+    4: double StdError
+    5: i64 Lower
+    6: i64 Upper
+    7: string Estimator
+}
+
+struct ImportLogCmd {
+    1: string Key,
+    2: binary Sketch,
+    3: i64 Expiry = 0,
+    4: bool Merge
+}
+
+struct SketchResponse {
+    1: string Key,
+    2: Status Status,
+    3: binary Sketch
+}
+
+struct SetCardinalityResponse {
+    1: Status Status,
+    2: i64 Cardinality,
+    3: i64 ErrorBound
+}
+
+struct TTLResponse {
+    1: string Key,
+    2: Status Status,
+    3: i64 TTL,
+    4: i64 Expiry
+}
+
+struct KeyTTL {
+    1: string Key,
+    2: i64 TTL
+}
+
+struct ScanResponse {
+    1: Status Status,
+    2: string Cursor,
+    3: list<KeyTTL> Keys
+}
+
+struct LogInfoResponse {
+    1: string Key,
+    2: Status Status,
+    3: Algorithm Algorithm,
+    4: i32 Precision,
+    5: bool Salted,
+    6: i64 Expiry,
+    7: i64 NonZeroRegisters,
+    8: i64 PendingUpdates,
+    9: i64 SerializedSize,
+    10: bool Persisted,
+    11: i64 CreateTime,
+    12: i64 ModifyTime
 }
 
 service HllService {
     Status AddLog(1:AddLogCmd addLog)
     Status Update(1:UpdateLogCmd upd)
     Status UpdateM(1:UpdateLogMValCmd mupd)
+    Status UpdateHashes(1:UpdateLogHashesCmd hupd)
     Status UpdateExpiry(1:UpdateExpiryCmd exp)
+    Status ExpireAt(1:ExpireAtCmd exp)
+    TTLResponse GetTTL(1:string Key)
+    Status Persist(1:string Key)
     Status DelLog(1:string key)
-    CardinalityResponse GetCardinality(1:string Key)
+    CardinalityResponse GetCardinality(1:string Key, 2:string Estimator)
+    Status Merge(1:MergeLogCmd mrg)
+    CardinalityResponse GetUnionCardinality(1:list<string> Keys, 2:string Estimator)
+    SetCardinalityResponse GetIntersectionCardinality(1:string Key1, 2:string Key2)
+    SetCardinalityResponse GetDifferenceCardinality(1:string Key1, 2:string Key2)
+    SketchResponse ExportLog(1:string Key)
+    Status ImportLog(1:ImportLogCmd imp)
+    ScanResponse Scan(1:string Cursor, 2:string Match, 3:i32 Count)
+    LogInfoResponse GetLogInfo(1:string Key)
+    Status Rename(1:RenameLogCmd ren)
+    Status Copy(1:CopyLogCmd cpy)
 }